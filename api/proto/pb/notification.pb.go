@@ -10,6 +10,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -23,10 +24,28 @@ const (
 )
 
 type SendNotificationRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	To            string                 `protobuf:"bytes,1,opt,name=to,proto3" json:"to,omitempty"`
-	TemplateName  string                 `protobuf:"bytes,2,opt,name=template_name,json=templateName,proto3" json:"template_name,omitempty"`
-	Data          *structpb.Struct       `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	To           string                 `protobuf:"bytes,1,opt,name=to,proto3" json:"to,omitempty"`
+	TemplateName string                 `protobuf:"bytes,2,opt,name=template_name,json=templateName,proto3" json:"template_name,omitempty"`
+	Data         *structpb.Struct       `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// Optional. Requests with a key that was already delivered are not sent again.
+	IdempotencyKey string `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// Optional, e.g. "de-DE". Falls back to "de" and then the default template.
+	Locale      string        `protobuf:"bytes,5,opt,name=locale,proto3" json:"locale,omitempty"`
+	Attachments []*Attachment `protobuf:"bytes,6,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	// Optional. Further To addresses besides `to`, which stays the primary
+	// recipient exposed to templates as {{.email}}.
+	AdditionalTo []string `protobuf:"bytes,7,rep,name=additional_to,json=additionalTo,proto3" json:"additional_to,omitempty"`
+	Cc           []string `protobuf:"bytes,8,rep,name=cc,proto3" json:"cc,omitempty"`
+	Bcc          []string `protobuf:"bytes,9,rep,name=bcc,proto3" json:"bcc,omitempty"`
+	ReplyTo      []string `protobuf:"bytes,10,rep,name=reply_to,json=replyTo,proto3" json:"reply_to,omitempty"`
+	// Optional. "email", "sms", "webhook", "chat" or "inapp"; defaults to
+	// the channel of the template's primary route, and then also delivers
+	// over the topic's other routes. For SMS, `to` is an E.164 phone number
+	// such as "+14155550123"; for webhooks and chat it is the name of a
+	// registered endpoint; for inapp it is the ERP user ID. The extra
+	// addresses and attachments above are only allowed for email.
+	Channel       string `protobuf:"bytes,11,opt,name=channel,proto3" json:"channel,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -82,17 +101,156 @@ func (x *SendNotificationRequest) GetData() *structpb.Struct {
 	return nil
 }
 
-type SendNotificationResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+func (x *SendNotificationRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *SendNotificationRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *SendNotificationRequest) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+func (x *SendNotificationRequest) GetAdditionalTo() []string {
+	if x != nil {
+		return x.AdditionalTo
+	}
+	return nil
+}
+
+func (x *SendNotificationRequest) GetCc() []string {
+	if x != nil {
+		return x.Cc
+	}
+	return nil
+}
+
+func (x *SendNotificationRequest) GetBcc() []string {
+	if x != nil {
+		return x.Bcc
+	}
+	return nil
+}
+
+func (x *SendNotificationRequest) GetReplyTo() []string {
+	if x != nil {
+		return x.ReplyTo
+	}
+	return nil
+}
+
+func (x *SendNotificationRequest) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+// A file sent with a notification. Set exactly one of content or blob_path.
+type Attachment struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Filename string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	// Optional. Guessed from the filename or content when empty.
+	ContentType string `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// Inline file content, subject to ATTACHMENT_MAX_INLINE_BYTES.
+	Content []byte `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	// Path of a file in the service's blob store (ATTACHMENT_DIR), for large files.
+	BlobPath string `protobuf:"bytes,4,opt,name=blob_path,json=blobPath,proto3" json:"blob_path,omitempty"`
+	// Optional. Embeds the file inline for the HTML body to reference as cid:<content_id>.
+	ContentId     string `protobuf:"bytes,5,opt,name=content_id,json=contentId,proto3" json:"content_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_notification_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment) ProtoMessage() {}
+
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Attachment) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *Attachment) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *Attachment) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *Attachment) GetBlobPath() string {
+	if x != nil {
+		return x.BlobPath
+	}
+	return ""
+}
+
+func (x *Attachment) GetContentId() string {
+	if x != nil {
+		return x.ContentId
+	}
+	return ""
+}
+
+type SendNotificationResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// Identifies the notification in status lookups, replays and logs.
+	NotificationId string `protobuf:"bytes,3,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
 func (x *SendNotificationResponse) Reset() {
 	*x = SendNotificationResponse{}
-	mi := &file_notification_proto_msgTypes[1]
+	mi := &file_notification_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -104,7 +262,7 @@ func (x *SendNotificationResponse) String() string {
 func (*SendNotificationResponse) ProtoMessage() {}
 
 func (x *SendNotificationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_notification_proto_msgTypes[1]
+	mi := &file_notification_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -117,7 +275,7 @@ func (x *SendNotificationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SendNotificationResponse.ProtoReflect.Descriptor instead.
 func (*SendNotificationResponse) Descriptor() ([]byte, []int) {
-	return file_notification_proto_rawDescGZIP(), []int{1}
+	return file_notification_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *SendNotificationResponse) GetSuccess() bool {
@@ -134,20 +292,2242 @@ func (x *SendNotificationResponse) GetMessage() string {
 	return ""
 }
 
-var File_notification_proto protoreflect.FileDescriptor
+func (x *SendNotificationResponse) GetNotificationId() string {
+	if x != nil {
+		return x.NotificationId
+	}
+	return ""
+}
 
-const file_notification_proto_rawDesc = "" +
-	"\n" +
-	"\x12notification.proto\x12\fnotification\x1a\x1cgoogle/protobuf/struct.proto\"{\n" +
-	"\x17SendNotificationRequest\x12\x0e\n" +
-	"\x02to\x18\x01 \x01(\tR\x02to\x12#\n" +
-	"\rtemplate_name\x18\x02 \x01(\tR\ftemplateName\x12+\n" +
-	"\x04data\x18\x03 \x01(\v2\x17.google.protobuf.StructR\x04data\"N\n" +
-	"\x18SendNotificationResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage2z\n" +
+// Selects failed notification logs to re-enqueue. At least one selector is required.
+type ReplayNotificationsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TemplateName    string                 `protobuf:"bytes,1,opt,name=template_name,json=templateName,proto3" json:"template_name,omitempty"`
+	Recipient       string                 `protobuf:"bytes,2,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	AttemptedAfter  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=attempted_after,json=attemptedAfter,proto3" json:"attempted_after,omitempty"`
+	AttemptedBefore *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=attempted_before,json=attemptedBefore,proto3" json:"attempted_before,omitempty"`
+	LogIds          []int32                `protobuf:"varint,5,rep,packed,name=log_ids,json=logIds,proto3" json:"log_ids,omitempty"`
+	Limit           int32                  `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ReplayNotificationsRequest) Reset() {
+	*x = ReplayNotificationsRequest{}
+	mi := &file_notification_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayNotificationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayNotificationsRequest) ProtoMessage() {}
+
+func (x *ReplayNotificationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayNotificationsRequest.ProtoReflect.Descriptor instead.
+func (*ReplayNotificationsRequest) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ReplayNotificationsRequest) GetTemplateName() string {
+	if x != nil {
+		return x.TemplateName
+	}
+	return ""
+}
+
+func (x *ReplayNotificationsRequest) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+func (x *ReplayNotificationsRequest) GetAttemptedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AttemptedAfter
+	}
+	return nil
+}
+
+func (x *ReplayNotificationsRequest) GetAttemptedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AttemptedBefore
+	}
+	return nil
+}
+
+func (x *ReplayNotificationsRequest) GetLogIds() []int32 {
+	if x != nil {
+		return x.LogIds
+	}
+	return nil
+}
+
+func (x *ReplayNotificationsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ReplayedNotification struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LogId         int32                  `protobuf:"varint,1,opt,name=log_id,json=logId,proto3" json:"log_id,omitempty"`
+	TemplateName  string                 `protobuf:"bytes,2,opt,name=template_name,json=templateName,proto3" json:"template_name,omitempty"`
+	Recipient     string                 `protobuf:"bytes,3,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Topic         string                 `protobuf:"bytes,4,opt,name=topic,proto3" json:"topic,omitempty"`
+	Queued        bool                   `protobuf:"varint,5,opt,name=queued,proto3" json:"queued,omitempty"`
+	Error         string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayedNotification) Reset() {
+	*x = ReplayedNotification{}
+	mi := &file_notification_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayedNotification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayedNotification) ProtoMessage() {}
+
+func (x *ReplayedNotification) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayedNotification.ProtoReflect.Descriptor instead.
+func (*ReplayedNotification) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ReplayedNotification) GetLogId() int32 {
+	if x != nil {
+		return x.LogId
+	}
+	return 0
+}
+
+func (x *ReplayedNotification) GetTemplateName() string {
+	if x != nil {
+		return x.TemplateName
+	}
+	return ""
+}
+
+func (x *ReplayedNotification) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+func (x *ReplayedNotification) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *ReplayedNotification) GetQueued() bool {
+	if x != nil {
+		return x.Queued
+	}
+	return false
+}
+
+func (x *ReplayedNotification) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ReplayNotificationsResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Notifications []*ReplayedNotification `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayNotificationsResponse) Reset() {
+	*x = ReplayNotificationsResponse{}
+	mi := &file_notification_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayNotificationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayNotificationsResponse) ProtoMessage() {}
+
+func (x *ReplayNotificationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayNotificationsResponse.ProtoReflect.Descriptor instead.
+func (*ReplayNotificationsResponse) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ReplayNotificationsResponse) GetNotifications() []*ReplayedNotification {
+	if x != nil {
+		return x.Notifications
+	}
+	return nil
+}
+
+// A single recorded notification attempt from notification_logs.
+type NotificationLog struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Recipient      string                 `protobuf:"bytes,2,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	TemplateName   string                 `protobuf:"bytes,3,opt,name=template_name,json=templateName,proto3" json:"template_name,omitempty"`
+	Status         string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Details        string                 `protobuf:"bytes,5,opt,name=details,proto3" json:"details,omitempty"`
+	AttemptedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=attempted_at,json=attemptedAt,proto3" json:"attempted_at,omitempty"`
+	ReplayOf       int32                  `protobuf:"varint,7,opt,name=replay_of,json=replayOf,proto3" json:"replay_of,omitempty"`
+	ReplayedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=replayed_at,json=replayedAt,proto3" json:"replayed_at,omitempty"`
+	NotificationId string                 `protobuf:"bytes,9,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
+	// The template locale actually used, after fallback.
+	Locale string `protobuf:"bytes,10,opt,name=locale,proto3" json:"locale,omitempty"`
+	// Every To address, starting with recipient.
+	To      []string `protobuf:"bytes,11,rep,name=to,proto3" json:"to,omitempty"`
+	Cc      []string `protobuf:"bytes,12,rep,name=cc,proto3" json:"cc,omitempty"`
+	Bcc     []string `protobuf:"bytes,13,rep,name=bcc,proto3" json:"bcc,omitempty"`
+	ReplyTo []string `protobuf:"bytes,14,rep,name=reply_to,json=replyTo,proto3" json:"reply_to,omitempty"`
+	// The email provider that delivered it and the ID it assigned; set on
+	// "sent" entries only.
+	Provider          string `protobuf:"bytes,15,opt,name=provider,proto3" json:"provider,omitempty"`
+	ProviderMessageId string `protobuf:"bytes,16,opt,name=provider_message_id,json=providerMessageId,proto3" json:"provider_message_id,omitempty"`
+	// "email", "sms", "webhook", "chat" or "inapp"; recipient holds a phone
+	// number, endpoint name or user ID for the latter four.
+	Channel string `protobuf:"bytes,17,opt,name=channel,proto3" json:"channel,omitempty"`
+	// The HTTP status a webhook or chat endpoint answered with, or 0.
+	ResponseCode  int32 `protobuf:"varint,18,opt,name=response_code,json=responseCode,proto3" json:"response_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotificationLog) Reset() {
+	*x = NotificationLog{}
+	mi := &file_notification_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationLog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationLog) ProtoMessage() {}
+
+func (x *NotificationLog) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationLog.ProtoReflect.Descriptor instead.
+func (*NotificationLog) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *NotificationLog) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *NotificationLog) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+func (x *NotificationLog) GetTemplateName() string {
+	if x != nil {
+		return x.TemplateName
+	}
+	return ""
+}
+
+func (x *NotificationLog) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *NotificationLog) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+func (x *NotificationLog) GetAttemptedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AttemptedAt
+	}
+	return nil
+}
+
+func (x *NotificationLog) GetReplayOf() int32 {
+	if x != nil {
+		return x.ReplayOf
+	}
+	return 0
+}
+
+func (x *NotificationLog) GetReplayedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReplayedAt
+	}
+	return nil
+}
+
+func (x *NotificationLog) GetNotificationId() string {
+	if x != nil {
+		return x.NotificationId
+	}
+	return ""
+}
+
+func (x *NotificationLog) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *NotificationLog) GetTo() []string {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *NotificationLog) GetCc() []string {
+	if x != nil {
+		return x.Cc
+	}
+	return nil
+}
+
+func (x *NotificationLog) GetBcc() []string {
+	if x != nil {
+		return x.Bcc
+	}
+	return nil
+}
+
+func (x *NotificationLog) GetReplyTo() []string {
+	if x != nil {
+		return x.ReplyTo
+	}
+	return nil
+}
+
+func (x *NotificationLog) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *NotificationLog) GetProviderMessageId() string {
+	if x != nil {
+		return x.ProviderMessageId
+	}
+	return ""
+}
+
+func (x *NotificationLog) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *NotificationLog) GetResponseCode() int32 {
+	if x != nil {
+		return x.ResponseCode
+	}
+	return 0
+}
+
+// Looks up a notification by ID (latest attempt wins) or a single attempt by log ID.
+type GetNotificationStatusRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	LogId          int32                  `protobuf:"varint,1,opt,name=log_id,json=logId,proto3" json:"log_id,omitempty"`
+	NotificationId string                 `protobuf:"bytes,2,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetNotificationStatusRequest) Reset() {
+	*x = GetNotificationStatusRequest{}
+	mi := &file_notification_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNotificationStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNotificationStatusRequest) ProtoMessage() {}
+
+func (x *GetNotificationStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNotificationStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetNotificationStatusRequest) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetNotificationStatusRequest) GetLogId() int32 {
+	if x != nil {
+		return x.LogId
+	}
+	return 0
+}
+
+func (x *GetNotificationStatusRequest) GetNotificationId() string {
+	if x != nil {
+		return x.NotificationId
+	}
+	return ""
+}
+
+type GetNotificationStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Notification  *NotificationLog       `protobuf:"bytes,1,opt,name=notification,proto3" json:"notification,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNotificationStatusResponse) Reset() {
+	*x = GetNotificationStatusResponse{}
+	mi := &file_notification_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNotificationStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNotificationStatusResponse) ProtoMessage() {}
+
+func (x *GetNotificationStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNotificationStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetNotificationStatusResponse) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetNotificationStatusResponse) GetNotification() *NotificationLog {
+	if x != nil {
+		return x.Notification
+	}
+	return nil
+}
+
+// Lists notification attempts newest first. All filters are optional.
+type ListNotificationsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Recipient       string                 `protobuf:"bytes,1,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	TemplateName    string                 `protobuf:"bytes,2,opt,name=template_name,json=templateName,proto3" json:"template_name,omitempty"`
+	Status          string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	AttemptedAfter  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=attempted_after,json=attemptedAfter,proto3" json:"attempted_after,omitempty"`
+	AttemptedBefore *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=attempted_before,json=attemptedBefore,proto3" json:"attempted_before,omitempty"`
+	PageSize        int32                  `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken       string                 `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	NotificationId  string                 `protobuf:"bytes,8,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListNotificationsRequest) Reset() {
+	*x = ListNotificationsRequest{}
+	mi := &file_notification_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotificationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotificationsRequest) ProtoMessage() {}
+
+func (x *ListNotificationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotificationsRequest.ProtoReflect.Descriptor instead.
+func (*ListNotificationsRequest) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListNotificationsRequest) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+func (x *ListNotificationsRequest) GetTemplateName() string {
+	if x != nil {
+		return x.TemplateName
+	}
+	return ""
+}
+
+func (x *ListNotificationsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListNotificationsRequest) GetAttemptedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AttemptedAfter
+	}
+	return nil
+}
+
+func (x *ListNotificationsRequest) GetAttemptedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AttemptedBefore
+	}
+	return nil
+}
+
+func (x *ListNotificationsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListNotificationsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListNotificationsRequest) GetNotificationId() string {
+	if x != nil {
+		return x.NotificationId
+	}
+	return ""
+}
+
+type ListNotificationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Notifications []*NotificationLog     `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNotificationsResponse) Reset() {
+	*x = ListNotificationsResponse{}
+	mi := &file_notification_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotificationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotificationsResponse) ProtoMessage() {}
+
+func (x *ListNotificationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotificationsResponse.ProtoReflect.Descriptor instead.
+func (*ListNotificationsResponse) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListNotificationsResponse) GetNotifications() []*NotificationLog {
+	if x != nil {
+		return x.Notifications
+	}
+	return nil
+}
+
+func (x *ListNotificationsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Renders a template exactly as it would be sent, without sending or logging it.
+type RenderTemplateRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	TemplateName string                 `protobuf:"bytes,1,opt,name=template_name,json=templateName,proto3" json:"template_name,omitempty"`
+	Data         *structpb.Struct       `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// Optional. Made available to the template as {{.email}}.
+	To string `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	// Optional. Resolved with the same fallback chain as SendNotification.
+	Locale        string `protobuf:"bytes,4,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenderTemplateRequest) Reset() {
+	*x = RenderTemplateRequest{}
+	mi := &file_notification_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderTemplateRequest) ProtoMessage() {}
+
+func (x *RenderTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderTemplateRequest.ProtoReflect.Descriptor instead.
+func (*RenderTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RenderTemplateRequest) GetTemplateName() string {
+	if x != nil {
+		return x.TemplateName
+	}
+	return ""
+}
+
+func (x *RenderTemplateRequest) GetData() *structpb.Struct {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *RenderTemplateRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *RenderTemplateRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type RenderTemplateResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Subject  string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	BodyHtml string                 `protobuf:"bytes,2,opt,name=body_html,json=bodyHtml,proto3" json:"body_html,omitempty"`
+	BodyText string                 `protobuf:"bytes,3,opt,name=body_text,json=bodyText,proto3" json:"body_text,omitempty"`
+	Warnings []string               `protobuf:"bytes,4,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	// The template locale actually rendered, after fallback.
+	Locale        string `protobuf:"bytes,5,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenderTemplateResponse) Reset() {
+	*x = RenderTemplateResponse{}
+	mi := &file_notification_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderTemplateResponse) ProtoMessage() {}
+
+func (x *RenderTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderTemplateResponse.ProtoReflect.Descriptor instead.
+func (*RenderTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RenderTemplateResponse) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *RenderTemplateResponse) GetBodyHtml() string {
+	if x != nil {
+		return x.BodyHtml
+	}
+	return ""
+}
+
+func (x *RenderTemplateResponse) GetBodyText() string {
+	if x != nil {
+		return x.BodyText
+	}
+	return ""
+}
+
+func (x *RenderTemplateResponse) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+func (x *RenderTemplateResponse) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+// Variables and defaults of a template, mirroring meta.json.
+type TemplateMeta struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Description       string                 `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"`
+	RequiredVariables []string               `protobuf:"bytes,2,rep,name=required_variables,json=requiredVariables,proto3" json:"required_variables,omitempty"`
+	OptionalVariables []string               `protobuf:"bytes,3,rep,name=optional_variables,json=optionalVariables,proto3" json:"optional_variables,omitempty"`
+	Defaults          *structpb.Struct       `protobuf:"bytes,4,opt,name=defaults,proto3" json:"defaults,omitempty"`
+	// Move <style> rules into inline style attributes after rendering.
+	InlineCss bool `protobuf:"varint,5,opt,name=inline_css,json=inlineCss,proto3" json:"inline_css,omitempty"`
+	// Optional sender override, e.g. "Billing <billing@example.com>".
+	From          string `protobuf:"bytes,6,opt,name=from,proto3" json:"from,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TemplateMeta) Reset() {
+	*x = TemplateMeta{}
+	mi := &file_notification_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TemplateMeta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TemplateMeta) ProtoMessage() {}
+
+func (x *TemplateMeta) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TemplateMeta.ProtoReflect.Descriptor instead.
+func (*TemplateMeta) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TemplateMeta) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *TemplateMeta) GetRequiredVariables() []string {
+	if x != nil {
+		return x.RequiredVariables
+	}
+	return nil
+}
+
+func (x *TemplateMeta) GetOptionalVariables() []string {
+	if x != nil {
+		return x.OptionalVariables
+	}
+	return nil
+}
+
+func (x *TemplateMeta) GetDefaults() *structpb.Struct {
+	if x != nil {
+		return x.Defaults
+	}
+	return nil
+}
+
+func (x *TemplateMeta) GetInlineCss() bool {
+	if x != nil {
+		return x.InlineCss
+	}
+	return false
+}
+
+func (x *TemplateMeta) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+// One immutable version of a template.
+type TemplateVersion struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Version  int32                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Subject  string                 `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	BodyHtml string                 `protobuf:"bytes,3,opt,name=body_html,json=bodyHtml,proto3" json:"body_html,omitempty"`
+	BodyText string                 `protobuf:"bytes,4,opt,name=body_text,json=bodyText,proto3" json:"body_text,omitempty"`
+	Meta     *TemplateMeta          `protobuf:"bytes,5,opt,name=meta,proto3" json:"meta,omitempty"`
+	// Bodies for the other channels, as in body.sms.txt, body.webhook.json,
+	// body.chat.json and body.inapp.txt. body_html may be left empty when
+	// one of them is set.
+	BodySms       string `protobuf:"bytes,6,opt,name=body_sms,json=bodySms,proto3" json:"body_sms,omitempty"`
+	BodyWebhook   string `protobuf:"bytes,7,opt,name=body_webhook,json=bodyWebhook,proto3" json:"body_webhook,omitempty"`
+	BodyChat      string `protobuf:"bytes,8,opt,name=body_chat,json=bodyChat,proto3" json:"body_chat,omitempty"`
+	BodyInapp     string `protobuf:"bytes,9,opt,name=body_inapp,json=bodyInapp,proto3" json:"body_inapp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TemplateVersion) Reset() {
+	*x = TemplateVersion{}
+	mi := &file_notification_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TemplateVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TemplateVersion) ProtoMessage() {}
+
+func (x *TemplateVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TemplateVersion.ProtoReflect.Descriptor instead.
+func (*TemplateVersion) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TemplateVersion) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *TemplateVersion) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *TemplateVersion) GetBodyHtml() string {
+	if x != nil {
+		return x.BodyHtml
+	}
+	return ""
+}
+
+func (x *TemplateVersion) GetBodyText() string {
+	if x != nil {
+		return x.BodyText
+	}
+	return ""
+}
+
+func (x *TemplateVersion) GetMeta() *TemplateMeta {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *TemplateVersion) GetBodySms() string {
+	if x != nil {
+		return x.BodySms
+	}
+	return ""
+}
+
+func (x *TemplateVersion) GetBodyWebhook() string {
+	if x != nil {
+		return x.BodyWebhook
+	}
+	return ""
+}
+
+func (x *TemplateVersion) GetBodyChat() string {
+	if x != nil {
+		return x.BodyChat
+	}
+	return ""
+}
+
+func (x *TemplateVersion) GetBodyInapp() string {
+	if x != nil {
+		return x.BodyInapp
+	}
+	return ""
+}
+
+type TemplateSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ActiveVersion int32                  `protobuf:"varint,2,opt,name=active_version,json=activeVersion,proto3" json:"active_version,omitempty"`
+	LatestVersion int32                  `protobuf:"varint,3,opt,name=latest_version,json=latestVersion,proto3" json:"latest_version,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Empty for the default variant.
+	Locale        string `protobuf:"bytes,6,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TemplateSummary) Reset() {
+	*x = TemplateSummary{}
+	mi := &file_notification_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TemplateSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TemplateSummary) ProtoMessage() {}
+
+func (x *TemplateSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TemplateSummary.ProtoReflect.Descriptor instead.
+func (*TemplateSummary) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TemplateSummary) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TemplateSummary) GetActiveVersion() int32 {
+	if x != nil {
+		return x.ActiveVersion
+	}
+	return 0
+}
+
+func (x *TemplateSummary) GetLatestVersion() int32 {
+	if x != nil {
+		return x.LatestVersion
+	}
+	return 0
+}
+
+func (x *TemplateSummary) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *TemplateSummary) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *TemplateSummary) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type CreateTemplateRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Name     string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Content  *TemplateVersion       `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Activate bool                   `protobuf:"varint,3,opt,name=activate,proto3" json:"activate,omitempty"`
+	// Optional. Each locale of a template is versioned independently.
+	Locale        string `protobuf:"bytes,4,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTemplateRequest) Reset() {
+	*x = CreateTemplateRequest{}
+	mi := &file_notification_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTemplateRequest) ProtoMessage() {}
+
+func (x *CreateTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTemplateRequest.ProtoReflect.Descriptor instead.
+func (*CreateTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CreateTemplateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateTemplateRequest) GetContent() *TemplateVersion {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *CreateTemplateRequest) GetActivate() bool {
+	if x != nil {
+		return x.Activate
+	}
+	return false
+}
+
+func (x *CreateTemplateRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+// Adds a new version to an existing template.
+type UpdateTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Content       *TemplateVersion       `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Activate      bool                   `protobuf:"varint,3,opt,name=activate,proto3" json:"activate,omitempty"`
+	Locale        string                 `protobuf:"bytes,4,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTemplateRequest) Reset() {
+	*x = UpdateTemplateRequest{}
+	mi := &file_notification_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTemplateRequest) ProtoMessage() {}
+
+func (x *UpdateTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTemplateRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UpdateTemplateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateTemplateRequest) GetContent() *TemplateVersion {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *UpdateTemplateRequest) GetActivate() bool {
+	if x != nil {
+		return x.Activate
+	}
+	return false
+}
+
+func (x *UpdateTemplateRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type TemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Template      *TemplateSummary       `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	Version       *TemplateVersion       `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TemplateResponse) Reset() {
+	*x = TemplateResponse{}
+	mi := &file_notification_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TemplateResponse) ProtoMessage() {}
+
+func (x *TemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TemplateResponse.ProtoReflect.Descriptor instead.
+func (*TemplateResponse) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *TemplateResponse) GetTemplate() *TemplateSummary {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+func (x *TemplateResponse) GetVersion() *TemplateVersion {
+	if x != nil {
+		return x.Version
+	}
+	return nil
+}
+
+type ListTemplatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTemplatesRequest) Reset() {
+	*x = ListTemplatesRequest{}
+	mi := &file_notification_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTemplatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTemplatesRequest) ProtoMessage() {}
+
+func (x *ListTemplatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTemplatesRequest.ProtoReflect.Descriptor instead.
+func (*ListTemplatesRequest) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{19}
+}
+
+type ListTemplatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Templates     []*TemplateSummary     `protobuf:"bytes,1,rep,name=templates,proto3" json:"templates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTemplatesResponse) Reset() {
+	*x = ListTemplatesResponse{}
+	mi := &file_notification_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTemplatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTemplatesResponse) ProtoMessage() {}
+
+func (x *ListTemplatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTemplatesResponse.ProtoReflect.Descriptor instead.
+func (*ListTemplatesResponse) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListTemplatesResponse) GetTemplates() []*TemplateSummary {
+	if x != nil {
+		return x.Templates
+	}
+	return nil
+}
+
+// Version 0 returns the active version.
+type GetTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Locale        string                 `protobuf:"bytes,3,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTemplateRequest) Reset() {
+	*x = GetTemplateRequest{}
+	mi := &file_notification_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTemplateRequest) ProtoMessage() {}
+
+func (x *GetTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTemplateRequest.ProtoReflect.Descriptor instead.
+func (*GetTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetTemplateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetTemplateRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *GetTemplateRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type ActivateVersionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version       int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Locale        string                 `protobuf:"bytes,3,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActivateVersionRequest) Reset() {
+	*x = ActivateVersionRequest{}
+	mi := &file_notification_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActivateVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActivateVersionRequest) ProtoMessage() {}
+
+func (x *ActivateVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActivateVersionRequest.ProtoReflect.Descriptor instead.
+func (*ActivateVersionRequest) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ActivateVersionRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ActivateVersionRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ActivateVersionRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type DeleteTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Locale        string                 `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTemplateRequest) Reset() {
+	*x = DeleteTemplateRequest{}
+	mi := &file_notification_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTemplateRequest) ProtoMessage() {}
+
+func (x *DeleteTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTemplateRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *DeleteTemplateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DeleteTemplateRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type DeleteTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTemplateResponse) Reset() {
+	*x = DeleteTemplateResponse{}
+	mi := &file_notification_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTemplateResponse) ProtoMessage() {}
+
+func (x *DeleteTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTemplateResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{24}
+}
+
+// A notification delivered over the "inapp" channel to a user's inbox.
+type InboxNotification struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	NotificationId string                 `protobuf:"bytes,3,opt,name=notification_id,json=notificationId,proto3" json:"notification_id,omitempty"`
+	TemplateName   string                 `protobuf:"bytes,4,opt,name=template_name,json=templateName,proto3" json:"template_name,omitempty"`
+	// Rendered from the template's subject.
+	Title string `protobuf:"bytes,5,opt,name=title,proto3" json:"title,omitempty"`
+	// Rendered from the template's body.inapp.txt.
+	Body      string                 `protobuf:"bytes,6,opt,name=body,proto3" json:"body,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Unset while the notification is unread.
+	ReadAt        *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=read_at,json=readAt,proto3" json:"read_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InboxNotification) Reset() {
+	*x = InboxNotification{}
+	mi := &file_notification_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InboxNotification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InboxNotification) ProtoMessage() {}
+
+func (x *InboxNotification) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InboxNotification.ProtoReflect.Descriptor instead.
+func (*InboxNotification) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *InboxNotification) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *InboxNotification) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *InboxNotification) GetNotificationId() string {
+	if x != nil {
+		return x.NotificationId
+	}
+	return ""
+}
+
+func (x *InboxNotification) GetTemplateName() string {
+	if x != nil {
+		return x.TemplateName
+	}
+	return ""
+}
+
+func (x *InboxNotification) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *InboxNotification) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *InboxNotification) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *InboxNotification) GetReadAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReadAt
+	}
+	return nil
+}
+
+// Lists a user's inbox newest first.
+type ListInboxRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UnreadOnly    bool                   `protobuf:"varint,2,opt,name=unread_only,json=unreadOnly,proto3" json:"unread_only,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInboxRequest) Reset() {
+	*x = ListInboxRequest{}
+	mi := &file_notification_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInboxRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInboxRequest) ProtoMessage() {}
+
+func (x *ListInboxRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInboxRequest.ProtoReflect.Descriptor instead.
+func (*ListInboxRequest) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListInboxRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListInboxRequest) GetUnreadOnly() bool {
+	if x != nil {
+		return x.UnreadOnly
+	}
+	return false
+}
+
+func (x *ListInboxRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListInboxRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListInboxResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Notifications []*InboxNotification   `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// Unread notifications in the whole inbox, for a badge.
+	UnreadCount   int64 `protobuf:"varint,3,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInboxResponse) Reset() {
+	*x = ListInboxResponse{}
+	mi := &file_notification_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInboxResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInboxResponse) ProtoMessage() {}
+
+func (x *ListInboxResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInboxResponse.ProtoReflect.Descriptor instead.
+func (*ListInboxResponse) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListInboxResponse) GetNotifications() []*InboxNotification {
+	if x != nil {
+		return x.Notifications
+	}
+	return nil
+}
+
+func (x *ListInboxResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListInboxResponse) GetUnreadCount() int64 {
+	if x != nil {
+		return x.UnreadCount
+	}
+	return 0
+}
+
+type MarkReadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Ids           []int64                `protobuf:"varint,2,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkReadRequest) Reset() {
+	*x = MarkReadRequest{}
+	mi := &file_notification_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkReadRequest) ProtoMessage() {}
+
+func (x *MarkReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkReadRequest.ProtoReflect.Descriptor instead.
+func (*MarkReadRequest) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *MarkReadRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MarkReadRequest) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type MarkReadResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Notifications that were unread before the call.
+	Updated       int64 `protobuf:"varint,1,opt,name=updated,proto3" json:"updated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkReadResponse) Reset() {
+	*x = MarkReadResponse{}
+	mi := &file_notification_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkReadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkReadResponse) ProtoMessage() {}
+
+func (x *MarkReadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkReadResponse.ProtoReflect.Descriptor instead.
+func (*MarkReadResponse) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *MarkReadResponse) GetUpdated() int64 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+type MarkAllReadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkAllReadRequest) Reset() {
+	*x = MarkAllReadRequest{}
+	mi := &file_notification_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkAllReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkAllReadRequest) ProtoMessage() {}
+
+func (x *MarkAllReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkAllReadRequest.ProtoReflect.Descriptor instead.
+func (*MarkAllReadRequest) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *MarkAllReadRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type MarkAllReadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Updated       int64                  `protobuf:"varint,1,opt,name=updated,proto3" json:"updated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkAllReadResponse) Reset() {
+	*x = MarkAllReadResponse{}
+	mi := &file_notification_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkAllReadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkAllReadResponse) ProtoMessage() {}
+
+func (x *MarkAllReadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkAllReadResponse.ProtoReflect.Descriptor instead.
+func (*MarkAllReadResponse) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *MarkAllReadResponse) GetUpdated() int64 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+// Streams notifications delivered to the user's inbox from now on. Clients
+// reconnect and call ListInbox to catch up on anything missed in between.
+type SubscribeInboxRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeInboxRequest) Reset() {
+	*x = SubscribeInboxRequest{}
+	mi := &file_notification_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeInboxRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeInboxRequest) ProtoMessage() {}
+
+func (x *SubscribeInboxRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeInboxRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeInboxRequest) Descriptor() ([]byte, []int) {
+	return file_notification_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SubscribeInboxRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+var File_notification_proto protoreflect.FileDescriptor
+
+const file_notification_proto_rawDesc = "" +
+	"\n" +
+	"\x12notification.proto\x12\fnotification\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf4\x02\n" +
+	"\x17SendNotificationRequest\x12\x0e\n" +
+	"\x02to\x18\x01 \x01(\tR\x02to\x12#\n" +
+	"\rtemplate_name\x18\x02 \x01(\tR\ftemplateName\x12+\n" +
+	"\x04data\x18\x03 \x01(\v2\x17.google.protobuf.StructR\x04data\x12'\n" +
+	"\x0fidempotency_key\x18\x04 \x01(\tR\x0eidempotencyKey\x12\x16\n" +
+	"\x06locale\x18\x05 \x01(\tR\x06locale\x12:\n" +
+	"\vattachments\x18\x06 \x03(\v2\x18.notification.AttachmentR\vattachments\x12#\n" +
+	"\radditional_to\x18\a \x03(\tR\fadditionalTo\x12\x0e\n" +
+	"\x02cc\x18\b \x03(\tR\x02cc\x12\x10\n" +
+	"\x03bcc\x18\t \x03(\tR\x03bcc\x12\x19\n" +
+	"\breply_to\x18\n" +
+	" \x03(\tR\areplyTo\x12\x18\n" +
+	"\achannel\x18\v \x01(\tR\achannel\"\xa1\x01\n" +
+	"\n" +
+	"Attachment\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\fR\acontent\x12\x1b\n" +
+	"\tblob_path\x18\x04 \x01(\tR\bblobPath\x12\x1d\n" +
+	"\n" +
+	"content_id\x18\x05 \x01(\tR\tcontentId\"w\n" +
+	"\x18SendNotificationResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12'\n" +
+	"\x0fnotification_id\x18\x03 \x01(\tR\x0enotificationId\"\x9a\x02\n" +
+	"\x1aReplayNotificationsRequest\x12#\n" +
+	"\rtemplate_name\x18\x01 \x01(\tR\ftemplateName\x12\x1c\n" +
+	"\trecipient\x18\x02 \x01(\tR\trecipient\x12C\n" +
+	"\x0fattempted_after\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x0eattemptedAfter\x12E\n" +
+	"\x10attempted_before\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x0fattemptedBefore\x12\x17\n" +
+	"\alog_ids\x18\x05 \x03(\x05R\x06logIds\x12\x14\n" +
+	"\x05limit\x18\x06 \x01(\x05R\x05limit\"\xb4\x01\n" +
+	"\x14ReplayedNotification\x12\x15\n" +
+	"\x06log_id\x18\x01 \x01(\x05R\x05logId\x12#\n" +
+	"\rtemplate_name\x18\x02 \x01(\tR\ftemplateName\x12\x1c\n" +
+	"\trecipient\x18\x03 \x01(\tR\trecipient\x12\x14\n" +
+	"\x05topic\x18\x04 \x01(\tR\x05topic\x12\x16\n" +
+	"\x06queued\x18\x05 \x01(\bR\x06queued\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\"g\n" +
+	"\x1bReplayNotificationsResponse\x12H\n" +
+	"\rnotifications\x18\x01 \x03(\v2\".notification.ReplayedNotificationR\rnotifications\"\xc8\x04\n" +
+	"\x0fNotificationLog\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1c\n" +
+	"\trecipient\x18\x02 \x01(\tR\trecipient\x12#\n" +
+	"\rtemplate_name\x18\x03 \x01(\tR\ftemplateName\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x18\n" +
+	"\adetails\x18\x05 \x01(\tR\adetails\x12=\n" +
+	"\fattempted_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vattemptedAt\x12\x1b\n" +
+	"\treplay_of\x18\a \x01(\x05R\breplayOf\x12;\n" +
+	"\vreplayed_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"replayedAt\x12'\n" +
+	"\x0fnotification_id\x18\t \x01(\tR\x0enotificationId\x12\x16\n" +
+	"\x06locale\x18\n" +
+	" \x01(\tR\x06locale\x12\x0e\n" +
+	"\x02to\x18\v \x03(\tR\x02to\x12\x0e\n" +
+	"\x02cc\x18\f \x03(\tR\x02cc\x12\x10\n" +
+	"\x03bcc\x18\r \x03(\tR\x03bcc\x12\x19\n" +
+	"\breply_to\x18\x0e \x03(\tR\areplyTo\x12\x1a\n" +
+	"\bprovider\x18\x0f \x01(\tR\bprovider\x12.\n" +
+	"\x13provider_message_id\x18\x10 \x01(\tR\x11providerMessageId\x12\x18\n" +
+	"\achannel\x18\x11 \x01(\tR\achannel\x12#\n" +
+	"\rresponse_code\x18\x12 \x01(\x05R\fresponseCode\"^\n" +
+	"\x1cGetNotificationStatusRequest\x12\x15\n" +
+	"\x06log_id\x18\x01 \x01(\x05R\x05logId\x12'\n" +
+	"\x0fnotification_id\x18\x02 \x01(\tR\x0enotificationId\"b\n" +
+	"\x1dGetNotificationStatusResponse\x12A\n" +
+	"\fnotification\x18\x01 \x01(\v2\x1d.notification.NotificationLogR\fnotification\"\xe6\x02\n" +
+	"\x18ListNotificationsRequest\x12\x1c\n" +
+	"\trecipient\x18\x01 \x01(\tR\trecipient\x12#\n" +
+	"\rtemplate_name\x18\x02 \x01(\tR\ftemplateName\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12C\n" +
+	"\x0fattempted_after\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x0eattemptedAfter\x12E\n" +
+	"\x10attempted_before\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x0fattemptedBefore\x12\x1b\n" +
+	"\tpage_size\x18\x06 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\a \x01(\tR\tpageToken\x12'\n" +
+	"\x0fnotification_id\x18\b \x01(\tR\x0enotificationId\"\x88\x01\n" +
+	"\x19ListNotificationsResponse\x12C\n" +
+	"\rnotifications\x18\x01 \x03(\v2\x1d.notification.NotificationLogR\rnotifications\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x91\x01\n" +
+	"\x15RenderTemplateRequest\x12#\n" +
+	"\rtemplate_name\x18\x01 \x01(\tR\ftemplateName\x12+\n" +
+	"\x04data\x18\x02 \x01(\v2\x17.google.protobuf.StructR\x04data\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\x12\x16\n" +
+	"\x06locale\x18\x04 \x01(\tR\x06locale\"\xa0\x01\n" +
+	"\x16RenderTemplateResponse\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12\x1b\n" +
+	"\tbody_html\x18\x02 \x01(\tR\bbodyHtml\x12\x1b\n" +
+	"\tbody_text\x18\x03 \x01(\tR\bbodyText\x12\x1a\n" +
+	"\bwarnings\x18\x04 \x03(\tR\bwarnings\x12\x16\n" +
+	"\x06locale\x18\x05 \x01(\tR\x06locale\"\xf6\x01\n" +
+	"\fTemplateMeta\x12 \n" +
+	"\vdescription\x18\x01 \x01(\tR\vdescription\x12-\n" +
+	"\x12required_variables\x18\x02 \x03(\tR\x11requiredVariables\x12-\n" +
+	"\x12optional_variables\x18\x03 \x03(\tR\x11optionalVariables\x123\n" +
+	"\bdefaults\x18\x04 \x01(\v2\x17.google.protobuf.StructR\bdefaults\x12\x1d\n" +
+	"\n" +
+	"inline_css\x18\x05 \x01(\bR\tinlineCss\x12\x12\n" +
+	"\x04from\x18\x06 \x01(\tR\x04from\"\xa9\x02\n" +
+	"\x0fTemplateVersion\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x05R\aversion\x12\x18\n" +
+	"\asubject\x18\x02 \x01(\tR\asubject\x12\x1b\n" +
+	"\tbody_html\x18\x03 \x01(\tR\bbodyHtml\x12\x1b\n" +
+	"\tbody_text\x18\x04 \x01(\tR\bbodyText\x12.\n" +
+	"\x04meta\x18\x05 \x01(\v2\x1a.notification.TemplateMetaR\x04meta\x12\x19\n" +
+	"\bbody_sms\x18\x06 \x01(\tR\abodySms\x12!\n" +
+	"\fbody_webhook\x18\a \x01(\tR\vbodyWebhook\x12\x1b\n" +
+	"\tbody_chat\x18\b \x01(\tR\bbodyChat\x12\x1d\n" +
+	"\n" +
+	"body_inapp\x18\t \x01(\tR\tbodyInapp\"\x81\x02\n" +
+	"\x0fTemplateSummary\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12%\n" +
+	"\x0eactive_version\x18\x02 \x01(\x05R\ractiveVersion\x12%\n" +
+	"\x0elatest_version\x18\x03 \x01(\x05R\rlatestVersion\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x16\n" +
+	"\x06locale\x18\x06 \x01(\tR\x06locale\"\x98\x01\n" +
+	"\x15CreateTemplateRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x127\n" +
+	"\acontent\x18\x02 \x01(\v2\x1d.notification.TemplateVersionR\acontent\x12\x1a\n" +
+	"\bactivate\x18\x03 \x01(\bR\bactivate\x12\x16\n" +
+	"\x06locale\x18\x04 \x01(\tR\x06locale\"\x98\x01\n" +
+	"\x15UpdateTemplateRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x127\n" +
+	"\acontent\x18\x02 \x01(\v2\x1d.notification.TemplateVersionR\acontent\x12\x1a\n" +
+	"\bactivate\x18\x03 \x01(\bR\bactivate\x12\x16\n" +
+	"\x06locale\x18\x04 \x01(\tR\x06locale\"\x86\x01\n" +
+	"\x10TemplateResponse\x129\n" +
+	"\btemplate\x18\x01 \x01(\v2\x1d.notification.TemplateSummaryR\btemplate\x127\n" +
+	"\aversion\x18\x02 \x01(\v2\x1d.notification.TemplateVersionR\aversion\"\x16\n" +
+	"\x14ListTemplatesRequest\"T\n" +
+	"\x15ListTemplatesResponse\x12;\n" +
+	"\ttemplates\x18\x01 \x03(\v2\x1d.notification.TemplateSummaryR\ttemplates\"Z\n" +
+	"\x12GetTemplateRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12\x16\n" +
+	"\x06locale\x18\x03 \x01(\tR\x06locale\"^\n" +
+	"\x16ActivateVersionRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12\x16\n" +
+	"\x06locale\x18\x03 \x01(\tR\x06locale\"C\n" +
+	"\x15DeleteTemplateRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06locale\x18\x02 \x01(\tR\x06locale\"\x18\n" +
+	"\x16DeleteTemplateResponse\"\xa4\x02\n" +
+	"\x11InboxNotification\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12'\n" +
+	"\x0fnotification_id\x18\x03 \x01(\tR\x0enotificationId\x12#\n" +
+	"\rtemplate_name\x18\x04 \x01(\tR\ftemplateName\x12\x14\n" +
+	"\x05title\x18\x05 \x01(\tR\x05title\x12\x12\n" +
+	"\x04body\x18\x06 \x01(\tR\x04body\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x123\n" +
+	"\aread_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x06readAt\"\x88\x01\n" +
+	"\x10ListInboxRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vunread_only\x18\x02 \x01(\bR\n" +
+	"unreadOnly\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\"\xa5\x01\n" +
+	"\x11ListInboxResponse\x12E\n" +
+	"\rnotifications\x18\x01 \x03(\v2\x1f.notification.InboxNotificationR\rnotifications\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12!\n" +
+	"\funread_count\x18\x03 \x01(\x03R\vunreadCount\"<\n" +
+	"\x0fMarkReadRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x10\n" +
+	"\x03ids\x18\x02 \x03(\x03R\x03ids\",\n" +
+	"\x10MarkReadResponse\x12\x18\n" +
+	"\aupdated\x18\x01 \x01(\x03R\aupdated\"-\n" +
+	"\x12MarkAllReadRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"/\n" +
+	"\x13MarkAllReadResponse\x12\x18\n" +
+	"\aupdated\x18\x01 \x01(\x03R\aupdated\"0\n" +
+	"\x15SubscribeInboxRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId2\xa3\x04\n" +
 	"\x13NotificationService\x12c\n" +
-	"\x10SendNotification\x12%.notification.SendNotificationRequest\x1a&.notification.SendNotificationResponse\"\x00B\x10Z\x0e./api/proto/pbb\x06proto3"
+	"\x10SendNotification\x12%.notification.SendNotificationRequest\x1a&.notification.SendNotificationResponse\"\x00\x12l\n" +
+	"\x13ReplayNotifications\x12(.notification.ReplayNotificationsRequest\x1a).notification.ReplayNotificationsResponse\"\x00\x12r\n" +
+	"\x15GetNotificationStatus\x12*.notification.GetNotificationStatusRequest\x1a+.notification.GetNotificationStatusResponse\"\x00\x12f\n" +
+	"\x11ListNotifications\x12&.notification.ListNotificationsRequest\x1a'.notification.ListNotificationsResponse\"\x00\x12]\n" +
+	"\x0eRenderTemplate\x12#.notification.RenderTemplateRequest\x1a$.notification.RenderTemplateResponse\"\x002\xab\x04\n" +
+	"\x0fTemplateService\x12W\n" +
+	"\x0eCreateTemplate\x12#.notification.CreateTemplateRequest\x1a\x1e.notification.TemplateResponse\"\x00\x12W\n" +
+	"\x0eUpdateTemplate\x12#.notification.UpdateTemplateRequest\x1a\x1e.notification.TemplateResponse\"\x00\x12Z\n" +
+	"\rListTemplates\x12\".notification.ListTemplatesRequest\x1a#.notification.ListTemplatesResponse\"\x00\x12Q\n" +
+	"\vGetTemplate\x12 .notification.GetTemplateRequest\x1a\x1e.notification.TemplateResponse\"\x00\x12X\n" +
+	"\x0fActivateVersion\x12$.notification.ActivateVersionRequest\x1a\x1d.notification.TemplateSummary\"\x00\x12]\n" +
+	"\x0eDeleteTemplate\x12#.notification.DeleteTemplateRequest\x1a$.notification.DeleteTemplateResponse\"\x002\xdd\x02\n" +
+	"\fInboxService\x12N\n" +
+	"\tListInbox\x12\x1e.notification.ListInboxRequest\x1a\x1f.notification.ListInboxResponse\"\x00\x12K\n" +
+	"\bMarkRead\x12\x1d.notification.MarkReadRequest\x1a\x1e.notification.MarkReadResponse\"\x00\x12T\n" +
+	"\vMarkAllRead\x12 .notification.MarkAllReadRequest\x1a!.notification.MarkAllReadResponse\"\x00\x12Z\n" +
+	"\x0eSubscribeInbox\x12#.notification.SubscribeInboxRequest\x1a\x1f.notification.InboxNotification\"\x000\x01B\x10Z\x0e./api/proto/pbb\x06proto3"
 
 var (
 	file_notification_proto_rawDescOnce sync.Once
@@ -161,21 +2541,104 @@ func file_notification_proto_rawDescGZIP() []byte {
 	return file_notification_proto_rawDescData
 }
 
-var file_notification_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_notification_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
 var file_notification_proto_goTypes = []any{
-	(*SendNotificationRequest)(nil),  // 0: notification.SendNotificationRequest
-	(*SendNotificationResponse)(nil), // 1: notification.SendNotificationResponse
-	(*structpb.Struct)(nil),          // 2: google.protobuf.Struct
+	(*SendNotificationRequest)(nil),       // 0: notification.SendNotificationRequest
+	(*Attachment)(nil),                    // 1: notification.Attachment
+	(*SendNotificationResponse)(nil),      // 2: notification.SendNotificationResponse
+	(*ReplayNotificationsRequest)(nil),    // 3: notification.ReplayNotificationsRequest
+	(*ReplayedNotification)(nil),          // 4: notification.ReplayedNotification
+	(*ReplayNotificationsResponse)(nil),   // 5: notification.ReplayNotificationsResponse
+	(*NotificationLog)(nil),               // 6: notification.NotificationLog
+	(*GetNotificationStatusRequest)(nil),  // 7: notification.GetNotificationStatusRequest
+	(*GetNotificationStatusResponse)(nil), // 8: notification.GetNotificationStatusResponse
+	(*ListNotificationsRequest)(nil),      // 9: notification.ListNotificationsRequest
+	(*ListNotificationsResponse)(nil),     // 10: notification.ListNotificationsResponse
+	(*RenderTemplateRequest)(nil),         // 11: notification.RenderTemplateRequest
+	(*RenderTemplateResponse)(nil),        // 12: notification.RenderTemplateResponse
+	(*TemplateMeta)(nil),                  // 13: notification.TemplateMeta
+	(*TemplateVersion)(nil),               // 14: notification.TemplateVersion
+	(*TemplateSummary)(nil),               // 15: notification.TemplateSummary
+	(*CreateTemplateRequest)(nil),         // 16: notification.CreateTemplateRequest
+	(*UpdateTemplateRequest)(nil),         // 17: notification.UpdateTemplateRequest
+	(*TemplateResponse)(nil),              // 18: notification.TemplateResponse
+	(*ListTemplatesRequest)(nil),          // 19: notification.ListTemplatesRequest
+	(*ListTemplatesResponse)(nil),         // 20: notification.ListTemplatesResponse
+	(*GetTemplateRequest)(nil),            // 21: notification.GetTemplateRequest
+	(*ActivateVersionRequest)(nil),        // 22: notification.ActivateVersionRequest
+	(*DeleteTemplateRequest)(nil),         // 23: notification.DeleteTemplateRequest
+	(*DeleteTemplateResponse)(nil),        // 24: notification.DeleteTemplateResponse
+	(*InboxNotification)(nil),             // 25: notification.InboxNotification
+	(*ListInboxRequest)(nil),              // 26: notification.ListInboxRequest
+	(*ListInboxResponse)(nil),             // 27: notification.ListInboxResponse
+	(*MarkReadRequest)(nil),               // 28: notification.MarkReadRequest
+	(*MarkReadResponse)(nil),              // 29: notification.MarkReadResponse
+	(*MarkAllReadRequest)(nil),            // 30: notification.MarkAllReadRequest
+	(*MarkAllReadResponse)(nil),           // 31: notification.MarkAllReadResponse
+	(*SubscribeInboxRequest)(nil),         // 32: notification.SubscribeInboxRequest
+	(*structpb.Struct)(nil),               // 33: google.protobuf.Struct
+	(*timestamppb.Timestamp)(nil),         // 34: google.protobuf.Timestamp
 }
 var file_notification_proto_depIdxs = []int32{
-	2, // 0: notification.SendNotificationRequest.data:type_name -> google.protobuf.Struct
-	0, // 1: notification.NotificationService.SendNotification:input_type -> notification.SendNotificationRequest
-	1, // 2: notification.NotificationService.SendNotification:output_type -> notification.SendNotificationResponse
-	2, // [2:3] is the sub-list for method output_type
-	1, // [1:2] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	33, // 0: notification.SendNotificationRequest.data:type_name -> google.protobuf.Struct
+	1,  // 1: notification.SendNotificationRequest.attachments:type_name -> notification.Attachment
+	34, // 2: notification.ReplayNotificationsRequest.attempted_after:type_name -> google.protobuf.Timestamp
+	34, // 3: notification.ReplayNotificationsRequest.attempted_before:type_name -> google.protobuf.Timestamp
+	4,  // 4: notification.ReplayNotificationsResponse.notifications:type_name -> notification.ReplayedNotification
+	34, // 5: notification.NotificationLog.attempted_at:type_name -> google.protobuf.Timestamp
+	34, // 6: notification.NotificationLog.replayed_at:type_name -> google.protobuf.Timestamp
+	6,  // 7: notification.GetNotificationStatusResponse.notification:type_name -> notification.NotificationLog
+	34, // 8: notification.ListNotificationsRequest.attempted_after:type_name -> google.protobuf.Timestamp
+	34, // 9: notification.ListNotificationsRequest.attempted_before:type_name -> google.protobuf.Timestamp
+	6,  // 10: notification.ListNotificationsResponse.notifications:type_name -> notification.NotificationLog
+	33, // 11: notification.RenderTemplateRequest.data:type_name -> google.protobuf.Struct
+	33, // 12: notification.TemplateMeta.defaults:type_name -> google.protobuf.Struct
+	13, // 13: notification.TemplateVersion.meta:type_name -> notification.TemplateMeta
+	34, // 14: notification.TemplateSummary.created_at:type_name -> google.protobuf.Timestamp
+	34, // 15: notification.TemplateSummary.updated_at:type_name -> google.protobuf.Timestamp
+	14, // 16: notification.CreateTemplateRequest.content:type_name -> notification.TemplateVersion
+	14, // 17: notification.UpdateTemplateRequest.content:type_name -> notification.TemplateVersion
+	15, // 18: notification.TemplateResponse.template:type_name -> notification.TemplateSummary
+	14, // 19: notification.TemplateResponse.version:type_name -> notification.TemplateVersion
+	15, // 20: notification.ListTemplatesResponse.templates:type_name -> notification.TemplateSummary
+	34, // 21: notification.InboxNotification.created_at:type_name -> google.protobuf.Timestamp
+	34, // 22: notification.InboxNotification.read_at:type_name -> google.protobuf.Timestamp
+	25, // 23: notification.ListInboxResponse.notifications:type_name -> notification.InboxNotification
+	0,  // 24: notification.NotificationService.SendNotification:input_type -> notification.SendNotificationRequest
+	3,  // 25: notification.NotificationService.ReplayNotifications:input_type -> notification.ReplayNotificationsRequest
+	7,  // 26: notification.NotificationService.GetNotificationStatus:input_type -> notification.GetNotificationStatusRequest
+	9,  // 27: notification.NotificationService.ListNotifications:input_type -> notification.ListNotificationsRequest
+	11, // 28: notification.NotificationService.RenderTemplate:input_type -> notification.RenderTemplateRequest
+	16, // 29: notification.TemplateService.CreateTemplate:input_type -> notification.CreateTemplateRequest
+	17, // 30: notification.TemplateService.UpdateTemplate:input_type -> notification.UpdateTemplateRequest
+	19, // 31: notification.TemplateService.ListTemplates:input_type -> notification.ListTemplatesRequest
+	21, // 32: notification.TemplateService.GetTemplate:input_type -> notification.GetTemplateRequest
+	22, // 33: notification.TemplateService.ActivateVersion:input_type -> notification.ActivateVersionRequest
+	23, // 34: notification.TemplateService.DeleteTemplate:input_type -> notification.DeleteTemplateRequest
+	26, // 35: notification.InboxService.ListInbox:input_type -> notification.ListInboxRequest
+	28, // 36: notification.InboxService.MarkRead:input_type -> notification.MarkReadRequest
+	30, // 37: notification.InboxService.MarkAllRead:input_type -> notification.MarkAllReadRequest
+	32, // 38: notification.InboxService.SubscribeInbox:input_type -> notification.SubscribeInboxRequest
+	2,  // 39: notification.NotificationService.SendNotification:output_type -> notification.SendNotificationResponse
+	5,  // 40: notification.NotificationService.ReplayNotifications:output_type -> notification.ReplayNotificationsResponse
+	8,  // 41: notification.NotificationService.GetNotificationStatus:output_type -> notification.GetNotificationStatusResponse
+	10, // 42: notification.NotificationService.ListNotifications:output_type -> notification.ListNotificationsResponse
+	12, // 43: notification.NotificationService.RenderTemplate:output_type -> notification.RenderTemplateResponse
+	18, // 44: notification.TemplateService.CreateTemplate:output_type -> notification.TemplateResponse
+	18, // 45: notification.TemplateService.UpdateTemplate:output_type -> notification.TemplateResponse
+	20, // 46: notification.TemplateService.ListTemplates:output_type -> notification.ListTemplatesResponse
+	18, // 47: notification.TemplateService.GetTemplate:output_type -> notification.TemplateResponse
+	15, // 48: notification.TemplateService.ActivateVersion:output_type -> notification.TemplateSummary
+	24, // 49: notification.TemplateService.DeleteTemplate:output_type -> notification.DeleteTemplateResponse
+	27, // 50: notification.InboxService.ListInbox:output_type -> notification.ListInboxResponse
+	29, // 51: notification.InboxService.MarkRead:output_type -> notification.MarkReadResponse
+	31, // 52: notification.InboxService.MarkAllRead:output_type -> notification.MarkAllReadResponse
+	25, // 53: notification.InboxService.SubscribeInbox:output_type -> notification.InboxNotification
+	39, // [39:54] is the sub-list for method output_type
+	24, // [24:39] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
 }
 
 func init() { file_notification_proto_init() }
@@ -189,9 +2652,9 @@ func file_notification_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_notification_proto_rawDesc), len(file_notification_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   33,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   3,
 		},
 		GoTypes:           file_notification_proto_goTypes,
 		DependencyIndexes: file_notification_proto_depIdxs,
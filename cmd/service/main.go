@@ -6,17 +6,26 @@ import (
 	"fmt"
 	"net"
 	"notification-service/api/proto/pb"
+	"notification-service/internal/adapters/blobstore"
+	"notification-service/internal/adapters/chat"
+	"notification-service/internal/adapters/httpmail"
+	"notification-service/internal/adapters/httpsms"
 	"notification-service/internal/adapters/kafka"
 	"notification-service/internal/adapters/logger"
 	"notification-service/internal/adapters/mailme"
 	repo "notification-service/internal/adapters/repository"
+	"notification-service/internal/adapters/routing"
+	"notification-service/internal/adapters/webhook"
 	"notification-service/internal/config"
+	"notification-service/internal/core/repository"
 	"notification-service/internal/core/services"
 	"notification-service/internal/ports/grpc_server"
 	"os"
 	"os/signal"
+	"strings"
 	"sync"
 	"syscall"
+	"time"
 
 	_ "github.com/lib/pq"
 	"github.com/sirupsen/logrus"
@@ -27,6 +36,15 @@ import (
 func main() {
 	cfg := config.LoadConfig()
 	log := logger.NewLogger()
+
+	// --- Subcommands ---
+	if len(os.Args) > 1 && os.Args[1] == "replay" {
+		if err := runReplay(cfg, log, os.Args[2:]); err != nil {
+			log.WithError(err).Fatal("Replay failed")
+		}
+		return
+	}
+
 	log.Info("Starting ERP CP Notification Service")
 
 	// --- Database Connection ---
@@ -42,9 +60,122 @@ func main() {
 	// --- Dependency Injection ---
 	// Dependencies for the CONSUMER side (email sending)
 	logRepo := repo.NewNotificationLogRepo(db)
-	templateRepo := repo.NewFileTemplateRepo(cfg.TemplatePath, log)
-	mailer := mailme.NewMailer(cfg.SmtpHost, cfg.SmtpPort, cfg.SmtpUser, cfg.SmtpPass, cfg.SmtpFrom, log)
-	notificationSvc := services.NewNotificationService(templateRepo, logRepo, mailer, log)
+	var templateRepo repository.TemplateRepository
+	var fileTemplateRepo *repo.FileTemplateRepo
+	var templateSvc *services.TemplateService // only for database-backed templates
+	switch cfg.TemplateSource {
+	case "file":
+		fileTemplateRepo = repo.NewFileTemplateRepo(cfg.TemplatePath, log)
+		templateRepo = fileTemplateRepo
+	case "db":
+		dbTemplateRepo := repo.NewDBTemplateRepo(db, log)
+		templateRepo = dbTemplateRepo
+		templateSvc = services.NewTemplateService(dbTemplateRepo, log)
+	default:
+		log.Fatalf("Unknown TEMPLATE_SOURCE %q, expected \"file\" or \"db\"", cfg.TemplateSource)
+	}
+	renderer := mailme.NewRenderer()
+	var senders []services.NamedSender
+	for _, name := range cfg.EmailProviders {
+		name = strings.TrimSpace(name)
+		var sender repository.EmailSender
+		switch name {
+		case mailme.ProviderSMTP:
+			mailer := mailme.NewMailer(cfg.SmtpHost, cfg.SmtpPort, cfg.SmtpUser, cfg.SmtpPass, cfg.SmtpFrom, log)
+			mailer.PoolSize = cfg.SmtpPoolSize
+			mailer.IdleTimeout = cfg.SmtpIdleTimeout
+			defer mailer.Close()
+			sender = mailer
+		case httpmail.ProviderHTTP:
+			if cfg.EmailHTTPEndpoint == "" {
+				log.Fatal("EMAIL_HTTP_ENDPOINT is required when EMAIL_PROVIDER includes \"http\"")
+			}
+			sender = httpmail.NewSender(cfg.EmailHTTPEndpoint, cfg.EmailHTTPAPIKey, cfg.SmtpFrom, cfg.EmailHTTPTimeout, log)
+		default:
+			log.Fatalf("Unknown EMAIL_PROVIDER %q, expected \"smtp\" or \"http\"", name)
+		}
+		for _, existing := range senders {
+			if existing.Name == name {
+				log.Fatalf("EMAIL_PROVIDER lists %q more than once", name)
+			}
+		}
+		senders = append(senders, services.NamedSender{Name: name, Sender: sender})
+	}
+	// With more than one provider, sends fail over down the list.
+	sender := senders[0].Sender
+	if len(senders) > 1 {
+		sender = services.NewFailoverSender(senders, services.BreakerPolicy{
+			Threshold: cfg.EmailFailoverThreshold,
+			Cooldown:  cfg.EmailFailoverCooldown,
+		}, log)
+	}
+	idempotencyRepo := repo.NewIdempotencyRepo(db)
+
+	// Attachments given as a blob path are read from ATTACHMENT_DIR, if set.
+	var blobs repository.BlobStore
+	if cfg.AttachmentDir != "" {
+		localBlobs, err := blobstore.NewLocalStore(cfg.AttachmentDir)
+		if err != nil {
+			log.WithError(err).Fatal("Failed to open attachment directory")
+		}
+		defer localBlobs.Close()
+		blobs = localBlobs
+	}
+	attachments := services.NewAttachmentResolver(blobs, services.AttachmentLimits{
+		MaxInlineBytes: cfg.AttachmentMaxInline,
+		MaxTotalBytes:  cfg.AttachmentMaxTotal,
+	})
+
+	// Channels other than email are only available once they are configured,
+	// apart from in-app notifications, which only need the database.
+	inboxSvc := services.NewInboxService(repo.NewInboxRepo(db), log)
+	enabled := []string{repository.ChannelEmail, repository.ChannelInApp}
+	channels := services.Channels{InApp: inboxSvc}
+	if cfg.SmsHTTPEndpoint != "" {
+		channels.SMS = &services.SMSChannel{
+			Sender:      httpsms.NewSender(cfg.SmsHTTPEndpoint, cfg.SmsHTTPAccountID, cfg.SmsHTTPAuthToken, cfg.SmsFrom, cfg.SmsHTTPTimeout, log),
+			MaxSegments: cfg.SmsMaxSegments,
+		}
+		enabled = append(enabled, repository.ChannelSMS)
+	}
+	if cfg.WebhooksPath != "" {
+		registry, err := webhook.LoadRegistry(cfg.WebhooksPath)
+		if err != nil {
+			log.WithError(err).Fatal("Failed to load webhook registry")
+		}
+		channels.Webhook = webhook.NewSender(registry, cfg.WebhookTimeout, log)
+		enabled = append(enabled, repository.ChannelWebhook)
+	}
+	if cfg.ChatWebhooksPath != "" {
+		registry, err := chat.LoadRegistry(cfg.ChatWebhooksPath)
+		if err != nil {
+			log.WithError(err).Fatal("Failed to load chat webhook registry")
+		}
+		channels.Chat = chat.NewSender(registry, cfg.ChatTimeout, cfg.ChatMinInterval, log)
+		enabled = append(enabled, repository.ChannelChat)
+	}
+	notificationSvc := services.NewNotificationService(templateRepo, logRepo, idempotencyRepo, cfg.IdempotencyTTL, attachments, renderer, sender, channels, log)
+
+	// --- Routing Table ---
+	// Misrouted topics must stop the service here rather than fail per message.
+	routes, err := routing.Load(cfg.RoutesPath)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to load routing table")
+	}
+	for _, route := range routes.SkipDisabled(enabled) {
+		log.WithFields(logrus.Fields{"topic": route.Topic, "channel": route.Channel}).Warn("Skipping route over a channel that is not configured")
+	}
+	if err := routes.CheckChannels(enabled); err != nil {
+		log.WithError(err).Fatal("Routing table uses channels that are not configured")
+	}
+	if err := routes.CheckTemplates(context.Background(), templateRepo); err != nil {
+		if cfg.TemplateSource == "file" {
+			log.WithError(err).Fatal("Routing table does not match available templates")
+		}
+		// Database templates are created through the TemplateService API,
+		// which must come up for a fresh database to be filled.
+		log.WithError(err).Warn("Routing table refers to templates missing from the database; their events fail until the templates are created")
+	}
 
 	// Dependency for the PRODUCER side (gRPC server)
 	kafkaProducer, err := kafka.NewProducer(cfg.KafkaBrokers, log)
@@ -52,6 +183,9 @@ func main() {
 		log.WithError(err).Fatal("Failed to create Kafka producer")
 	}
 	defer kafkaProducer.Close()
+	replaySvc := services.NewReplayService(logRepo, kafkaProducer, routes, log)
+	querySvc := services.NewQueryService(logRepo)
+	previewSvc := services.NewPreviewService(templateRepo, renderer)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	var wg sync.WaitGroup
@@ -59,12 +193,51 @@ func main() {
 	// --- Start Kafka Consumer ---
 	wg.Add(1)
 	go func() {
-		log.WithFields(logrus.Fields{"brokers": cfg.KafkaBrokers, "group": cfg.KafkaGroupID}).Info("Starting Kafka consumer group")
-		consumerHandler := kafka.NewConsumerGroupHandler(notificationSvc, log)
-		kafka.StartConsumerGroup(ctx, &wg, cfg.KafkaBrokers, cfg.KafkaTopics, cfg.KafkaGroupID, consumerHandler)
+		log.WithFields(logrus.Fields{"brokers": cfg.KafkaBrokers, "group": cfg.KafkaGroupID, "topics": routes.Topics()}).Info("Starting Kafka consumer group")
+		retry := kafka.RetryPolicy{
+			MaxAttempts:    cfg.RetryMax,
+			InitialBackoff: cfg.RetryInitial,
+			MaxBackoff:     cfg.RetryMaxWait,
+		}
+		consumerHandler := kafka.NewConsumerGroupHandler(notificationSvc, routes, kafkaProducer, cfg.DLQTopic, retry, log)
+		kafka.StartConsumerGroup(ctx, &wg, cfg.KafkaBrokers, routes.Topics(), cfg.KafkaGroupID, consumerHandler)
 		log.Info("Kafka consumer group stopped")
 	}()
 
+	// --- Watch Templates for Changes ---
+	if fileTemplateRepo != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fileTemplateRepo.Watch(ctx, cfg.TemplatePoll)
+		}()
+	}
+
+	// --- Push New Inbox Notifications to Subscribers ---
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := repo.ListenInbox(ctx, cfg.DBSource, inboxSvc, log); err != nil {
+			log.WithError(err).Error("Inbox listener failed, in-app notifications will not be pushed")
+		}
+	}()
+
+	// --- Purge Expired Idempotency Keys ---
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				notificationSvc.PurgeExpiredKeys(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
 	// --- Start gRPC Server ---
 	wg.Add(1)
 	go func() {
@@ -75,8 +248,12 @@ func main() {
 		}
 		grpcServer := grpc.NewServer()
 		// CORRECTED: The server now takes the producer, not the notification service.
-		server := grpc_server.NewGrpcServer(kafkaProducer, log)
+		server := grpc_server.NewGrpcServer(kafkaProducer, routes, templateRepo, attachments, replaySvc, querySvc, previewSvc, log)
 		pb.RegisterNotificationServiceServer(grpcServer, server)
+		if templateSvc != nil {
+			pb.RegisterTemplateServiceServer(grpcServer, grpc_server.NewTemplateServer(templateSvc, log))
+		}
+		pb.RegisterInboxServiceServer(grpcServer, grpc_server.NewInboxServer(inboxSvc, log))
 		reflection.Register(grpcServer)
 
 		log.Infof("gRPC server listening on port %s", cfg.GrpcPort)
@@ -88,6 +265,8 @@ func main() {
 
 		<-ctx.Done()
 		log.Info("Gracefully stopping gRPC server...")
+		// Inbox subscriptions stream until told otherwise; end them first.
+		inboxSvc.Close()
 		grpcServer.GracefulStop()
 		log.Info("gRPC server stopped")
 	}()
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"notification-service/internal/adapters/kafka"
+	repo "notification-service/internal/adapters/repository"
+	"notification-service/internal/adapters/routing"
+	"notification-service/internal/config"
+	"notification-service/internal/core/services"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// runReplay implements the "replay" subcommand, which re-enqueues failed
+// notifications without going through the gRPC API:
+//
+//	service replay -template welcome -after 2025-01-01T00:00:00Z
+//	service replay -ids 12,15
+func runReplay(cfg *config.Config, log *logrus.Logger, args []string) error {
+	fs := flag.NewFlagSet("replay", flag.ContinueOnError)
+	templateName := fs.String("template", "", "only replay logs for this template")
+	recipient := fs.String("recipient", "", "only replay logs for this recipient")
+	after := fs.String("after", "", "only replay logs attempted at or after this RFC3339 time")
+	before := fs.String("before", "", "only replay logs attempted before this RFC3339 time")
+	ids := fs.String("ids", "", "comma-separated notification log IDs to replay")
+	limit := fs.Int("limit", 0, "maximum number of logs to replay (default 100)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	filter := services.ReplayFilter{
+		TemplateName: *templateName,
+		Recipient:    *recipient,
+		Limit:        *limit,
+	}
+	var err error
+	if filter.After, err = parseTimeFlag("after", *after); err != nil {
+		return err
+	}
+	if filter.Before, err = parseTimeFlag("before", *before); err != nil {
+		return err
+	}
+	if *ids != "" {
+		for _, raw := range strings.Split(*ids, ",") {
+			id, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 32)
+			if err != nil {
+				return fmt.Errorf("invalid log ID %q: %w", raw, err)
+			}
+			filter.LogIDs = append(filter.LogIDs, int32(id))
+		}
+	}
+
+	if filter.IsEmpty() {
+		return services.ErrEmptyReplayFilter
+	}
+
+	db, err := sql.Open(cfg.DBDriver, cfg.DBSource)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	routes, err := routing.Load(cfg.RoutesPath)
+	if err != nil {
+		return err
+	}
+
+	producer, err := kafka.NewProducer(cfg.KafkaBrokers, log)
+	if err != nil {
+		return fmt.Errorf("failed to create Kafka producer: %w", err)
+	}
+	defer producer.Close()
+
+	replaySvc := services.NewReplayService(repo.NewNotificationLogRepo(db), producer, routes, log)
+	results, err := replaySvc.Replay(context.Background(), filter)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+			fmt.Printf("log %d (%s -> %s): FAILED: %v\n", result.LogID, result.TemplateName, result.Recipient, result.Err)
+			continue
+		}
+		fmt.Printf("log %d (%s -> %s): queued on %s\n", result.LogID, result.TemplateName, result.Recipient, result.Topic)
+	}
+	fmt.Printf("%d replayed, %d failed\n", len(results)-failed, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d notifications could not be replayed", failed)
+	}
+	return nil
+}
+
+func parseTimeFlag(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid -%s time %q: %w", name, value, err)
+	}
+	return t, nil
+}
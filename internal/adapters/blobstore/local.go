@@ -0,0 +1,65 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"notification-service/internal/core/repository"
+)
+
+// LocalStore serves blobs from a directory. Paths are relative to it and
+// cannot escape it.
+type LocalStore struct {
+	root *os.Root
+}
+
+func NewLocalStore(dir string) (*LocalStore, error) {
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return nil, fmt.Errorf("could not open blob directory %s: %w", dir, err)
+	}
+	return &LocalStore{root: root}, nil
+}
+
+func (s *LocalStore) Size(ctx context.Context, path string) (int64, error) {
+	info, err := s.root.Stat(filepath.FromSlash(path))
+	if err != nil {
+		return 0, s.wrap(path, err)
+	}
+	if !info.Mode().IsRegular() {
+		return 0, fmt.Errorf("%w: %s is not a file", repository.ErrBlobNotFound, path)
+	}
+	return info.Size(), nil
+}
+
+func (s *LocalStore) Read(ctx context.Context, path string) ([]byte, error) {
+	f, err := s.root.Open(filepath.FromSlash(path))
+	if err != nil {
+		return nil, s.wrap(path, err)
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("could not read blob %s: %w", path, err)
+	}
+	return data, nil
+}
+
+// Close releases the blob directory.
+func (s *LocalStore) Close() error {
+	return s.root.Close()
+}
+
+func (s *LocalStore) wrap(path string, err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: %s", repository.ErrBlobNotFound, path)
+	}
+	// os.Root reports paths escaping the directory as plain errors.
+	return fmt.Errorf("could not open blob %s: %w", path, err)
+}
@@ -0,0 +1,59 @@
+package chat
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// limiter spaces out posts to each webhook by at least interval. Slack
+// accepts about one message per second per incoming webhook and answers
+// bursts with 429s.
+type limiter struct {
+	interval time.Duration
+
+	mu   sync.Mutex
+	next map[string]time.Time // earliest time of the next post, per webhook
+}
+
+func newLimiter(interval time.Duration) *limiter {
+	return &limiter{
+		interval: interval,
+		next:     make(map[string]time.Time),
+	}
+}
+
+// wait reserves the next slot for the webhook and blocks until it comes up
+// or ctx is done.
+func (l *limiter) wait(ctx context.Context, webhook string) error {
+	l.mu.Lock()
+	now := time.Now()
+	slot := l.next[webhook]
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next[webhook] = slot.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := slot.Sub(now)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// backOff holds further posts to the webhook for d, after it answered 429.
+func (l *limiter) backOff(webhook string, d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if until := time.Now().Add(d); until.After(l.next[webhook]) {
+		l.next[webhook] = until
+	}
+}
@@ -0,0 +1,76 @@
+package chat
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+)
+
+// Webhook is a registered incoming webhook. Anyone holding its URL can post
+// to the channel, so the URL is read from the environment variable named by
+// URLEnv rather than kept in the registry file.
+type Webhook struct {
+	Name   string `json:"name"`
+	URLEnv string `json:"url_env"`
+
+	url string
+}
+
+// Registry maps webhook names, which notifications address, to URLs.
+type Registry struct {
+	webhooks map[string]Webhook
+}
+
+type registryFile struct {
+	Webhooks []Webhook `json:"webhooks"`
+}
+
+// LoadRegistry reads the webhook registry from a JSON file.
+func LoadRegistry(path string) (*Registry, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read chat webhook registry %s: %w", path, err)
+	}
+
+	var file registryFile
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return nil, fmt.Errorf("could not parse chat webhook registry %s: %w", path, err)
+	}
+
+	return NewRegistry(file.Webhooks)
+}
+
+// NewRegistry builds a registry, rejecting duplicate names and URLs that
+// are missing from the environment or not absolute https URLs.
+func NewRegistry(webhooks []Webhook) (*Registry, error) {
+	r := &Registry{webhooks: make(map[string]Webhook, len(webhooks))}
+	for i, webhook := range webhooks {
+		if webhook.Name == "" {
+			return nil, fmt.Errorf("webhook %d: name is required", i)
+		}
+		if _, exists := r.webhooks[webhook.Name]; exists {
+			return nil, fmt.Errorf("webhook %q: duplicate name", webhook.Name)
+		}
+		if webhook.URLEnv == "" {
+			return nil, fmt.Errorf("webhook %q: url_env is required", webhook.Name)
+		}
+		raw := os.Getenv(webhook.URLEnv)
+		if raw == "" {
+			return nil, fmt.Errorf("webhook %q: environment variable %s is not set", webhook.Name, webhook.URLEnv)
+		}
+		// The URL itself stays out of the error, since it is the credential.
+		if u, err := url.Parse(raw); err != nil || u.Scheme != "https" || u.Host == "" {
+			return nil, fmt.Errorf("webhook %q: %s must hold an absolute https URL", webhook.Name, webhook.URLEnv)
+		}
+		webhook.url = raw
+		r.webhooks[webhook.Name] = webhook
+	}
+	return r, nil
+}
+
+// Lookup returns the webhook registered under name.
+func (r *Registry) Lookup(name string) (Webhook, bool) {
+	webhook, ok := r.webhooks[name]
+	return webhook, ok
+}
@@ -0,0 +1,84 @@
+package chat
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"notification-service/internal/core/repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ProviderSlack is recorded as the provider of chat messages, since Slack
+// incoming webhooks are the only kind supported.
+const ProviderSlack = "slack"
+
+// Sender is a ChatSender for Slack incoming webhooks. The payload is posted
+// as is, so templates produce the whole message: {"text": ..., "blocks": [...]}.
+type Sender struct {
+	registry *Registry
+	client   *http.Client
+	limiter  *limiter
+	logger   *logrus.Logger
+}
+
+// NewSender creates a sender that posts to each webhook at most once per
+// minInterval.
+func NewSender(registry *Registry, timeout, minInterval time.Duration, logger *logrus.Logger) *Sender {
+	return &Sender{
+		registry: registry,
+		client:   &http.Client{Timeout: timeout},
+		limiter:  newLimiter(minInterval),
+		logger:   logger,
+	}
+}
+
+func (s *Sender) Send(ctx context.Context, message *repository.ChatMessage) (*repository.SendResult, error) {
+	webhook, ok := s.registry.Lookup(message.Webhook)
+	if !ok {
+		return nil, fmt.Errorf("%w: no chat webhook registered as %q", repository.ErrWebhookRejected, message.Webhook)
+	}
+	if err := s.limiter.wait(ctx, webhook.Name); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.url, bytes.NewReader(message.Payload))
+	if err != nil {
+		return nil, fmt.Errorf("could not build chat webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		// The error quotes the URL, which is the credential; leave it out.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return nil, fmt.Errorf("failed to post to chat webhook %q: %w", webhook.Name, err)
+	}
+	defer resp.Body.Close()
+
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			s.limiter.backOff(webhook.Name, time.Duration(seconds)*time.Second)
+		}
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, repository.NewStatusError("chat webhook", resp.StatusCode, raw, repository.ErrWebhookRejected)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"webhook":     webhook.Name,
+		"status_code": resp.StatusCode,
+	}).Info("chat message accepted by webhook")
+	return &repository.SendResult{Provider: ProviderSlack, ResponseCode: resp.StatusCode}, nil
+}
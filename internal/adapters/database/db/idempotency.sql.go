@@ -0,0 +1,113 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: idempotency.sql
+
+package db
+
+import (
+	"context"
+	"time"
+)
+
+const claimIdempotencyKey = `-- name: ClaimIdempotencyKey :one
+INSERT INTO idempotency_keys (
+    key,
+    notification_id,
+    status,
+    created_at,
+    expires_at
+) VALUES (
+    $1, $2, 'pending', $3, $4
+)
+ON CONFLICT (key) DO UPDATE
+SET notification_id = EXCLUDED.notification_id,
+    status = EXCLUDED.status,
+    created_at = EXCLUDED.created_at,
+    expires_at = EXCLUDED.expires_at
+WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+RETURNING key
+`
+
+type ClaimIdempotencyKeyParams struct {
+	Key            string
+	NotificationID string
+	CreatedAt      time.Time
+	ExpiresAt      time.Time
+}
+
+// Inserts the key as pending, or takes over one whose lease or TTL has run
+// out. Returns no row when the key is still held.
+func (q *Queries) ClaimIdempotencyKey(ctx context.Context, arg ClaimIdempotencyKeyParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, claimIdempotencyKey,
+		arg.Key,
+		arg.NotificationID,
+		arg.CreatedAt,
+		arg.ExpiresAt,
+	)
+	var key string
+	err := row.Scan(&key)
+	return key, err
+}
+
+const completeIdempotencyKey = `-- name: CompleteIdempotencyKey :execrows
+UPDATE idempotency_keys
+SET status = 'done',
+    expires_at = $3
+WHERE key = $1 AND notification_id = $2 AND status = 'pending'
+`
+
+type CompleteIdempotencyKeyParams struct {
+	Key            string
+	NotificationID string
+	ExpiresAt      time.Time
+}
+
+// Marks a pending key held by the notification as delivered, for the TTL.
+func (q *Queries) CompleteIdempotencyKey(ctx context.Context, arg CompleteIdempotencyKeyParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, completeIdempotencyKey, arg.Key, arg.NotificationID, arg.ExpiresAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteExpiredIdempotencyKeys = `-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM idempotency_keys
+WHERE expires_at <= $1
+`
+
+func (q *Queries) DeleteExpiredIdempotencyKeys(ctx context.Context, expiresAt time.Time) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteExpiredIdempotencyKeys, expiresAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getIdempotencyKeyStatus = `-- name: GetIdempotencyKeyStatus :one
+SELECT status FROM idempotency_keys
+WHERE key = $1
+`
+
+func (q *Queries) GetIdempotencyKeyStatus(ctx context.Context, key string) (string, error) {
+	row := q.db.QueryRowContext(ctx, getIdempotencyKeyStatus, key)
+	var status string
+	err := row.Scan(&status)
+	return status, err
+}
+
+const releaseIdempotencyKey = `-- name: ReleaseIdempotencyKey :exec
+DELETE FROM idempotency_keys
+WHERE key = $1 AND notification_id = $2 AND status = 'pending'
+`
+
+type ReleaseIdempotencyKeyParams struct {
+	Key            string
+	NotificationID string
+}
+
+func (q *Queries) ReleaseIdempotencyKey(ctx context.Context, arg ReleaseIdempotencyKeyParams) error {
+	_, err := q.db.ExecContext(ctx, releaseIdempotencyKey, arg.Key, arg.NotificationID)
+	return err
+}
@@ -0,0 +1,189 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: inbox.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const countUnreadInboxNotifications = `-- name: CountUnreadInboxNotifications :one
+SELECT COUNT(*) FROM inbox_notifications
+WHERE user_id = $1 AND read_at IS NULL
+`
+
+func (q *Queries) CountUnreadInboxNotifications(ctx context.Context, userID string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUnreadInboxNotifications, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createInboxNotification = `-- name: CreateInboxNotification :one
+INSERT INTO inbox_notifications (
+    user_id,
+    notification_id,
+    template_name,
+    title,
+    body,
+    created_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (user_id, notification_id) DO NOTHING
+RETURNING id, user_id, notification_id, template_name, title, body, created_at, read_at
+`
+
+type CreateInboxNotificationParams struct {
+	UserID         string
+	NotificationID string
+	TemplateName   string
+	Title          string
+	Body           string
+	CreatedAt      time.Time
+}
+
+// Returns no row when the notification is already in the user's inbox.
+func (q *Queries) CreateInboxNotification(ctx context.Context, arg CreateInboxNotificationParams) (InboxNotification, error) {
+	row := q.db.QueryRowContext(ctx, createInboxNotification,
+		arg.UserID,
+		arg.NotificationID,
+		arg.TemplateName,
+		arg.Title,
+		arg.Body,
+		arg.CreatedAt,
+	)
+	var i InboxNotification
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.NotificationID,
+		&i.TemplateName,
+		&i.Title,
+		&i.Body,
+		&i.CreatedAt,
+		&i.ReadAt,
+	)
+	return i, err
+}
+
+const getInboxNotification = `-- name: GetInboxNotification :one
+SELECT id, user_id, notification_id, template_name, title, body, created_at, read_at FROM inbox_notifications
+WHERE id = $1
+`
+
+func (q *Queries) GetInboxNotification(ctx context.Context, id int64) (InboxNotification, error) {
+	row := q.db.QueryRowContext(ctx, getInboxNotification, id)
+	var i InboxNotification
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.NotificationID,
+		&i.TemplateName,
+		&i.Title,
+		&i.Body,
+		&i.CreatedAt,
+		&i.ReadAt,
+	)
+	return i, err
+}
+
+const listInboxNotifications = `-- name: ListInboxNotifications :many
+SELECT id, user_id, notification_id, template_name, title, body, created_at, read_at FROM inbox_notifications
+WHERE user_id = $1
+  AND (NOT $2::boolean OR read_at IS NULL)
+  AND ($3::bigint IS NULL OR id < $3)
+ORDER BY id DESC
+LIMIT $4
+`
+
+type ListInboxNotificationsParams struct {
+	UserID     string
+	UnreadOnly bool
+	BeforeID   sql.NullInt64
+	RowLimit   int32
+}
+
+func (q *Queries) ListInboxNotifications(ctx context.Context, arg ListInboxNotificationsParams) ([]InboxNotification, error) {
+	rows, err := q.db.QueryContext(ctx, listInboxNotifications,
+		arg.UserID,
+		arg.UnreadOnly,
+		arg.BeforeID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []InboxNotification
+	for rows.Next() {
+		var i InboxNotification
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.NotificationID,
+			&i.TemplateName,
+			&i.Title,
+			&i.Body,
+			&i.CreatedAt,
+			&i.ReadAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markAllInboxNotificationsRead = `-- name: MarkAllInboxNotificationsRead :execrows
+UPDATE inbox_notifications
+SET read_at = $2
+WHERE user_id = $1 AND read_at IS NULL
+`
+
+type MarkAllInboxNotificationsReadParams struct {
+	UserID string
+	ReadAt sql.NullTime
+}
+
+func (q *Queries) MarkAllInboxNotificationsRead(ctx context.Context, arg MarkAllInboxNotificationsReadParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, markAllInboxNotificationsRead, arg.UserID, arg.ReadAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const markInboxNotificationsRead = `-- name: MarkInboxNotificationsRead :execrows
+UPDATE inbox_notifications
+SET read_at = $1
+WHERE user_id = $2
+  AND id = ANY($3::bigint[])
+  AND read_at IS NULL
+`
+
+type MarkInboxNotificationsReadParams struct {
+	ReadAt sql.NullTime
+	UserID string
+	Ids    []int64
+}
+
+func (q *Queries) MarkInboxNotificationsRead(ctx context.Context, arg MarkInboxNotificationsReadParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, markInboxNotificationsRead, arg.ReadAt, arg.UserID, pq.Array(arg.Ids))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
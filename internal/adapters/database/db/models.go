@@ -10,12 +10,66 @@ import (
 	"time"
 )
 
+type IdempotencyKey struct {
+	Key            string
+	NotificationID string
+	CreatedAt      time.Time
+	ExpiresAt      time.Time
+	Status         string
+}
+
+type InboxNotification struct {
+	ID             int64
+	UserID         string
+	NotificationID string
+	TemplateName   string
+	Title          string
+	Body           string
+	CreatedAt      time.Time
+	ReadAt         sql.NullTime
+}
+
 type NotificationLog struct {
-	ID           int32
-	Recipient    string
-	TemplateName string
-	Status       string
-	Details      sql.NullString
-	Data         json.RawMessage
-	AttemptedAt  time.Time
+	ID                int32
+	Recipient         string
+	TemplateName      string
+	Status            string
+	Details           sql.NullString
+	Data              json.RawMessage
+	AttemptedAt       time.Time
+	ReplayOf          sql.NullInt32
+	ReplayedAt        sql.NullTime
+	NotificationID    sql.NullString
+	IdempotencyKey    sql.NullString
+	Locale            sql.NullString
+	Recipients        json.RawMessage
+	Provider          sql.NullString
+	ProviderMessageID sql.NullString
+	Channel           string
+	ResponseCode      sql.NullInt32
+	Topic             sql.NullString
+}
+
+type Template struct {
+	ID              int32
+	Name            string
+	ActiveVersionID sql.NullInt32
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	Locale          string
+}
+
+type TemplateVersion struct {
+	ID          int32
+	TemplateID  int32
+	Version     int32
+	Subject     string
+	BodyHtml    string
+	BodyText    string
+	Meta        json.RawMessage
+	CreatedAt   time.Time
+	BodySms     string
+	BodyWebhook string
+	BodyChat    string
+	BodyInapp   string
 }
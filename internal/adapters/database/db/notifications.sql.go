@@ -10,6 +10,8 @@ import (
 	"database/sql"
 	"encoding/json"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 const createNotificationLog = `-- name: CreateNotificationLog :exec
@@ -19,19 +21,39 @@ INSERT INTO notification_logs (
     status,
     details,
     data,
-    attempted_at
+    attempted_at,
+    replay_of,
+    notification_id,
+    idempotency_key,
+    locale,
+    recipients,
+    provider,
+    provider_message_id,
+    channel,
+    response_code,
+    topic
 ) VALUES (
-    $1, $2, $3, $4, $5, $6
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16
 )
 `
 
 type CreateNotificationLogParams struct {
-	Recipient    string
-	TemplateName string
-	Status       string
-	Details      sql.NullString
-	Data         json.RawMessage
-	AttemptedAt  time.Time
+	Recipient         string
+	TemplateName      string
+	Status            string
+	Details           sql.NullString
+	Data              json.RawMessage
+	AttemptedAt       time.Time
+	ReplayOf          sql.NullInt32
+	NotificationID    sql.NullString
+	IdempotencyKey    sql.NullString
+	Locale            sql.NullString
+	Recipients        json.RawMessage
+	Provider          sql.NullString
+	ProviderMessageID sql.NullString
+	Channel           string
+	ResponseCode      sql.NullInt32
+	Topic             sql.NullString
 }
 
 func (q *Queries) CreateNotificationLog(ctx context.Context, arg CreateNotificationLogParams) error {
@@ -42,6 +64,242 @@ func (q *Queries) CreateNotificationLog(ctx context.Context, arg CreateNotificat
 		arg.Details,
 		arg.Data,
 		arg.AttemptedAt,
+		arg.ReplayOf,
+		arg.NotificationID,
+		arg.IdempotencyKey,
+		arg.Locale,
+		arg.Recipients,
+		arg.Provider,
+		arg.ProviderMessageID,
+		arg.Channel,
+		arg.ResponseCode,
+		arg.Topic,
+	)
+	return err
+}
+
+const getLatestNotificationLogByNotificationID = `-- name: GetLatestNotificationLogByNotificationID :one
+SELECT id, recipient, template_name, status, details, data, attempted_at, replay_of, replayed_at, notification_id, idempotency_key, locale, recipients, provider, provider_message_id, channel, response_code, topic FROM notification_logs
+WHERE notification_id = $1
+ORDER BY id DESC
+LIMIT 1
+`
+
+func (q *Queries) GetLatestNotificationLogByNotificationID(ctx context.Context, notificationID sql.NullString) (NotificationLog, error) {
+	row := q.db.QueryRowContext(ctx, getLatestNotificationLogByNotificationID, notificationID)
+	var i NotificationLog
+	err := row.Scan(
+		&i.ID,
+		&i.Recipient,
+		&i.TemplateName,
+		&i.Status,
+		&i.Details,
+		&i.Data,
+		&i.AttemptedAt,
+		&i.ReplayOf,
+		&i.ReplayedAt,
+		&i.NotificationID,
+		&i.IdempotencyKey,
+		&i.Locale,
+		&i.Recipients,
+		&i.Provider,
+		&i.ProviderMessageID,
+		&i.Channel,
+		&i.ResponseCode,
+		&i.Topic,
 	)
+	return i, err
+}
+
+const getNotificationLog = `-- name: GetNotificationLog :one
+SELECT id, recipient, template_name, status, details, data, attempted_at, replay_of, replayed_at, notification_id, idempotency_key, locale, recipients, provider, provider_message_id, channel, response_code, topic FROM notification_logs
+WHERE id = $1
+`
+
+func (q *Queries) GetNotificationLog(ctx context.Context, id int32) (NotificationLog, error) {
+	row := q.db.QueryRowContext(ctx, getNotificationLog, id)
+	var i NotificationLog
+	err := row.Scan(
+		&i.ID,
+		&i.Recipient,
+		&i.TemplateName,
+		&i.Status,
+		&i.Details,
+		&i.Data,
+		&i.AttemptedAt,
+		&i.ReplayOf,
+		&i.ReplayedAt,
+		&i.NotificationID,
+		&i.IdempotencyKey,
+		&i.Locale,
+		&i.Recipients,
+		&i.Provider,
+		&i.ProviderMessageID,
+		&i.Channel,
+		&i.ResponseCode,
+		&i.Topic,
+	)
+	return i, err
+}
+
+const listFailedNotificationLogs = `-- name: ListFailedNotificationLogs :many
+SELECT id, recipient, template_name, status, details, data, attempted_at, replay_of, replayed_at, notification_id, idempotency_key, locale, recipients, provider, provider_message_id, channel, response_code, topic FROM notification_logs
+WHERE status = 'failed'
+  AND replayed_at IS NULL
+  AND ($1::text IS NULL OR template_name = $1)
+  AND ($2::text IS NULL OR recipient = $2)
+  AND ($3::timestamptz IS NULL OR attempted_at >= $3)
+  AND ($4::timestamptz IS NULL OR attempted_at < $4)
+  AND (cardinality($5::int[]) = 0 OR id = ANY($5::int[]))
+ORDER BY id
+LIMIT $6
+`
+
+type ListFailedNotificationLogsParams struct {
+	TemplateName    sql.NullString
+	Recipient       sql.NullString
+	AttemptedAfter  sql.NullTime
+	AttemptedBefore sql.NullTime
+	Ids             []int32
+	RowLimit        int32
+}
+
+func (q *Queries) ListFailedNotificationLogs(ctx context.Context, arg ListFailedNotificationLogsParams) ([]NotificationLog, error) {
+	rows, err := q.db.QueryContext(ctx, listFailedNotificationLogs,
+		arg.TemplateName,
+		arg.Recipient,
+		arg.AttemptedAfter,
+		arg.AttemptedBefore,
+		pq.Array(arg.Ids),
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []NotificationLog
+	for rows.Next() {
+		var i NotificationLog
+		if err := rows.Scan(
+			&i.ID,
+			&i.Recipient,
+			&i.TemplateName,
+			&i.Status,
+			&i.Details,
+			&i.Data,
+			&i.AttemptedAt,
+			&i.ReplayOf,
+			&i.ReplayedAt,
+			&i.NotificationID,
+			&i.IdempotencyKey,
+			&i.Locale,
+			&i.Recipients,
+			&i.Provider,
+			&i.ProviderMessageID,
+			&i.Channel,
+			&i.ResponseCode,
+			&i.Topic,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listNotificationLogs = `-- name: ListNotificationLogs :many
+SELECT id, recipient, template_name, status, details, data, attempted_at, replay_of, replayed_at, notification_id, idempotency_key, locale, recipients, provider, provider_message_id, channel, response_code, topic FROM notification_logs
+WHERE ($1::text IS NULL OR notification_id = $1)
+  AND ($2::text IS NULL OR recipient = $2)
+  AND ($3::text IS NULL OR template_name = $3)
+  AND ($4::text IS NULL OR status = $4)
+  AND ($5::timestamptz IS NULL OR attempted_at >= $5)
+  AND ($6::timestamptz IS NULL OR attempted_at < $6)
+  AND ($7::int IS NULL OR id < $7)
+ORDER BY id DESC
+LIMIT $8
+`
+
+type ListNotificationLogsParams struct {
+	NotificationID  sql.NullString
+	Recipient       sql.NullString
+	TemplateName    sql.NullString
+	Status          sql.NullString
+	AttemptedAfter  sql.NullTime
+	AttemptedBefore sql.NullTime
+	BeforeID        sql.NullInt32
+	RowLimit        int32
+}
+
+func (q *Queries) ListNotificationLogs(ctx context.Context, arg ListNotificationLogsParams) ([]NotificationLog, error) {
+	rows, err := q.db.QueryContext(ctx, listNotificationLogs,
+		arg.NotificationID,
+		arg.Recipient,
+		arg.TemplateName,
+		arg.Status,
+		arg.AttemptedAfter,
+		arg.AttemptedBefore,
+		arg.BeforeID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []NotificationLog
+	for rows.Next() {
+		var i NotificationLog
+		if err := rows.Scan(
+			&i.ID,
+			&i.Recipient,
+			&i.TemplateName,
+			&i.Status,
+			&i.Details,
+			&i.Data,
+			&i.AttemptedAt,
+			&i.ReplayOf,
+			&i.ReplayedAt,
+			&i.NotificationID,
+			&i.IdempotencyKey,
+			&i.Locale,
+			&i.Recipients,
+			&i.Provider,
+			&i.ProviderMessageID,
+			&i.Channel,
+			&i.ResponseCode,
+			&i.Topic,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markNotificationLogReplayed = `-- name: MarkNotificationLogReplayed :exec
+UPDATE notification_logs
+SET replayed_at = $2
+WHERE id = $1
+`
+
+type MarkNotificationLogReplayedParams struct {
+	ID         int32
+	ReplayedAt sql.NullTime
+}
+
+func (q *Queries) MarkNotificationLogReplayed(ctx context.Context, arg MarkNotificationLogReplayedParams) error {
+	_, err := q.db.ExecContext(ctx, markNotificationLogReplayed, arg.ID, arg.ReplayedAt)
 	return err
 }
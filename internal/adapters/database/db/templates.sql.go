@@ -0,0 +1,342 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: templates.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+const createTemplate = `-- name: CreateTemplate :one
+INSERT INTO templates (name, locale)
+VALUES ($1, $2)
+RETURNING id, name, active_version_id, created_at, updated_at, locale
+`
+
+type CreateTemplateParams struct {
+	Name   string
+	Locale string
+}
+
+func (q *Queries) CreateTemplate(ctx context.Context, arg CreateTemplateParams) (Template, error) {
+	row := q.db.QueryRowContext(ctx, createTemplate, arg.Name, arg.Locale)
+	var i Template
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.ActiveVersionID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Locale,
+	)
+	return i, err
+}
+
+const createTemplateVersion = `-- name: CreateTemplateVersion :one
+INSERT INTO template_versions (
+    template_id,
+    version,
+    subject,
+    body_html,
+    body_text,
+    body_sms,
+    body_webhook,
+    body_chat,
+    body_inapp,
+    meta
+) VALUES (
+    $1,
+    (SELECT COALESCE(MAX(version), 0) + 1 FROM template_versions WHERE template_id = $1),
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8,
+    $9
+)
+RETURNING id, template_id, version, subject, body_html, body_text, meta, created_at, body_sms, body_webhook, body_chat, body_inapp
+`
+
+type CreateTemplateVersionParams struct {
+	TemplateID  int32
+	Subject     string
+	BodyHtml    string
+	BodyText    string
+	BodySms     string
+	BodyWebhook string
+	BodyChat    string
+	BodyInapp   string
+	Meta        json.RawMessage
+}
+
+func (q *Queries) CreateTemplateVersion(ctx context.Context, arg CreateTemplateVersionParams) (TemplateVersion, error) {
+	row := q.db.QueryRowContext(ctx, createTemplateVersion,
+		arg.TemplateID,
+		arg.Subject,
+		arg.BodyHtml,
+		arg.BodyText,
+		arg.BodySms,
+		arg.BodyWebhook,
+		arg.BodyChat,
+		arg.BodyInapp,
+		arg.Meta,
+	)
+	var i TemplateVersion
+	err := row.Scan(
+		&i.ID,
+		&i.TemplateID,
+		&i.Version,
+		&i.Subject,
+		&i.BodyHtml,
+		&i.BodyText,
+		&i.Meta,
+		&i.CreatedAt,
+		&i.BodySms,
+		&i.BodyWebhook,
+		&i.BodyChat,
+		&i.BodyInapp,
+	)
+	return i, err
+}
+
+const deleteTemplate = `-- name: DeleteTemplate :execrows
+DELETE FROM templates
+WHERE name = $1 AND locale = $2
+`
+
+type DeleteTemplateParams struct {
+	Name   string
+	Locale string
+}
+
+func (q *Queries) DeleteTemplate(ctx context.Context, arg DeleteTemplateParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteTemplate, arg.Name, arg.Locale)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getActiveTemplateVersion = `-- name: GetActiveTemplateVersion :one
+SELECT t.name, t.locale, v.id, v.template_id, v.version, v.subject, v.body_html, v.body_text, v.meta, v.created_at, v.body_sms, v.body_webhook, v.body_chat, v.body_inapp
+FROM templates t
+JOIN template_versions v ON v.id = t.active_version_id
+WHERE t.name = $1 AND t.locale = $2
+`
+
+type GetActiveTemplateVersionParams struct {
+	Name   string
+	Locale string
+}
+
+type GetActiveTemplateVersionRow struct {
+	Name        string
+	Locale      string
+	ID          int32
+	TemplateID  int32
+	Version     int32
+	Subject     string
+	BodyHtml    string
+	BodyText    string
+	Meta        json.RawMessage
+	CreatedAt   time.Time
+	BodySms     string
+	BodyWebhook string
+	BodyChat    string
+	BodyInapp   string
+}
+
+func (q *Queries) GetActiveTemplateVersion(ctx context.Context, arg GetActiveTemplateVersionParams) (GetActiveTemplateVersionRow, error) {
+	row := q.db.QueryRowContext(ctx, getActiveTemplateVersion, arg.Name, arg.Locale)
+	var i GetActiveTemplateVersionRow
+	err := row.Scan(
+		&i.Name,
+		&i.Locale,
+		&i.ID,
+		&i.TemplateID,
+		&i.Version,
+		&i.Subject,
+		&i.BodyHtml,
+		&i.BodyText,
+		&i.Meta,
+		&i.CreatedAt,
+		&i.BodySms,
+		&i.BodyWebhook,
+		&i.BodyChat,
+		&i.BodyInapp,
+	)
+	return i, err
+}
+
+const getTemplateSummary = `-- name: GetTemplateSummary :one
+SELECT t.name,
+       t.locale,
+       t.created_at,
+       t.updated_at,
+       COALESCE(av.version, 0)::int AS active_version,
+       COALESCE((SELECT MAX(version) FROM template_versions WHERE template_id = t.id), 0)::int AS latest_version
+FROM templates t
+LEFT JOIN template_versions av ON av.id = t.active_version_id
+WHERE t.name = $1 AND t.locale = $2
+`
+
+type GetTemplateSummaryParams struct {
+	Name   string
+	Locale string
+}
+
+type GetTemplateSummaryRow struct {
+	Name          string
+	Locale        string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	ActiveVersion int32
+	LatestVersion int32
+}
+
+func (q *Queries) GetTemplateSummary(ctx context.Context, arg GetTemplateSummaryParams) (GetTemplateSummaryRow, error) {
+	row := q.db.QueryRowContext(ctx, getTemplateSummary, arg.Name, arg.Locale)
+	var i GetTemplateSummaryRow
+	err := row.Scan(
+		&i.Name,
+		&i.Locale,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ActiveVersion,
+		&i.LatestVersion,
+	)
+	return i, err
+}
+
+const getTemplateVersion = `-- name: GetTemplateVersion :one
+SELECT v.id, v.template_id, v.version, v.subject, v.body_html, v.body_text, v.meta, v.created_at, v.body_sms, v.body_webhook, v.body_chat, v.body_inapp
+FROM template_versions v
+JOIN templates t ON t.id = v.template_id
+WHERE t.name = $1 AND t.locale = $2 AND v.version = $3
+`
+
+type GetTemplateVersionParams struct {
+	Name    string
+	Locale  string
+	Version int32
+}
+
+func (q *Queries) GetTemplateVersion(ctx context.Context, arg GetTemplateVersionParams) (TemplateVersion, error) {
+	row := q.db.QueryRowContext(ctx, getTemplateVersion, arg.Name, arg.Locale, arg.Version)
+	var i TemplateVersion
+	err := row.Scan(
+		&i.ID,
+		&i.TemplateID,
+		&i.Version,
+		&i.Subject,
+		&i.BodyHtml,
+		&i.BodyText,
+		&i.Meta,
+		&i.CreatedAt,
+		&i.BodySms,
+		&i.BodyWebhook,
+		&i.BodyChat,
+		&i.BodyInapp,
+	)
+	return i, err
+}
+
+const listTemplateSummaries = `-- name: ListTemplateSummaries :many
+SELECT t.name,
+       t.locale,
+       t.created_at,
+       t.updated_at,
+       COALESCE(av.version, 0)::int AS active_version,
+       COALESCE((SELECT MAX(version) FROM template_versions WHERE template_id = t.id), 0)::int AS latest_version
+FROM templates t
+LEFT JOIN template_versions av ON av.id = t.active_version_id
+ORDER BY t.name, t.locale
+`
+
+type ListTemplateSummariesRow struct {
+	Name          string
+	Locale        string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	ActiveVersion int32
+	LatestVersion int32
+}
+
+func (q *Queries) ListTemplateSummaries(ctx context.Context) ([]ListTemplateSummariesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listTemplateSummaries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTemplateSummariesRow
+	for rows.Next() {
+		var i ListTemplateSummariesRow
+		if err := rows.Scan(
+			&i.Name,
+			&i.Locale,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ActiveVersion,
+			&i.LatestVersion,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockTemplateByName = `-- name: LockTemplateByName :one
+SELECT id, name, active_version_id, created_at, updated_at, locale FROM templates
+WHERE name = $1 AND locale = $2
+FOR UPDATE
+`
+
+type LockTemplateByNameParams struct {
+	Name   string
+	Locale string
+}
+
+func (q *Queries) LockTemplateByName(ctx context.Context, arg LockTemplateByNameParams) (Template, error) {
+	row := q.db.QueryRowContext(ctx, lockTemplateByName, arg.Name, arg.Locale)
+	var i Template
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.ActiveVersionID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Locale,
+	)
+	return i, err
+}
+
+const setActiveTemplateVersion = `-- name: SetActiveTemplateVersion :exec
+UPDATE templates
+SET active_version_id = $2,
+    updated_at = NOW()
+WHERE id = $1
+`
+
+type SetActiveTemplateVersionParams struct {
+	ID              int32
+	ActiveVersionID sql.NullInt32
+}
+
+func (q *Queries) SetActiveTemplateVersion(ctx context.Context, arg SetActiveTemplateVersionParams) error {
+	_, err := q.db.ExecContext(ctx, setActiveTemplateVersion, arg.ID, arg.ActiveVersionID)
+	return err
+}
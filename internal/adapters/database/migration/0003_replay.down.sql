@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_notification_logs_replay_of;
+DROP INDEX IF EXISTS idx_notification_logs_status;
+
+ALTER TABLE notification_logs
+    DROP COLUMN IF EXISTS replayed_at,
+    DROP COLUMN IF EXISTS replay_of;
@@ -0,0 +1,7 @@
+-- Link replayed attempts back to the failed log they re-drive.
+ALTER TABLE notification_logs
+    ADD COLUMN replay_of INTEGER REFERENCES notification_logs(id),
+    ADD COLUMN replayed_at TIMESTAMPTZ;
+
+CREATE INDEX idx_notification_logs_status ON notification_logs (status, attempted_at);
+CREATE INDEX idx_notification_logs_replay_of ON notification_logs (replay_of);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_notification_logs_template_name;
+DROP INDEX IF EXISTS idx_notification_logs_recipient;
@@ -0,0 +1,3 @@
+-- Support status lookups by recipient and template, newest first.
+CREATE INDEX idx_notification_logs_recipient ON notification_logs (recipient, id DESC);
+CREATE INDEX idx_notification_logs_template_name ON notification_logs (template_name, id DESC);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_notification_logs_notification_id;
+
+ALTER TABLE notification_logs DROP COLUMN IF EXISTS notification_id;
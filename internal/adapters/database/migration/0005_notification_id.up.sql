@@ -0,0 +1,4 @@
+-- Correlate every attempt with the ID returned to the caller of SendNotification.
+ALTER TABLE notification_logs ADD COLUMN notification_id TEXT;
+
+CREATE INDEX idx_notification_logs_notification_id ON notification_logs (notification_id, id DESC);
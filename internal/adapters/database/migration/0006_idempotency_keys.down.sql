@@ -0,0 +1,3 @@
+ALTER TABLE notification_logs DROP COLUMN IF EXISTS idempotency_key;
+
+DROP TABLE IF EXISTS idempotency_keys;
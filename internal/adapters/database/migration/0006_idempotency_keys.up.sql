@@ -0,0 +1,11 @@
+-- Keys of notifications already handled, so redelivered events are not sent twice.
+CREATE TABLE idempotency_keys (
+    key TEXT PRIMARY KEY,
+    notification_id TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
+
+ALTER TABLE notification_logs ADD COLUMN idempotency_key TEXT;
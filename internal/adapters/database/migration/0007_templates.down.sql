@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS template_versions_immutable ON template_versions;
+DROP FUNCTION IF EXISTS reject_template_version_update();
+
+ALTER TABLE templates DROP CONSTRAINT IF EXISTS fk_templates_active_version;
+
+DROP TABLE IF EXISTS template_versions;
+DROP TABLE IF EXISTS templates;
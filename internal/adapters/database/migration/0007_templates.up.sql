@@ -0,0 +1,36 @@
+-- Templates stored in the database. Each edit creates a new immutable version;
+-- the template points at the version currently used for sending.
+CREATE TABLE templates (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL UNIQUE,
+    active_version_id INTEGER,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE template_versions (
+    id SERIAL PRIMARY KEY,
+    template_id INTEGER NOT NULL REFERENCES templates(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    subject TEXT NOT NULL,
+    body_html TEXT NOT NULL,
+    body_text TEXT NOT NULL DEFAULT '',
+    meta JSONB NOT NULL DEFAULT '{}'::jsonb,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (template_id, version)
+);
+
+ALTER TABLE templates
+    ADD CONSTRAINT fk_templates_active_version
+    FOREIGN KEY (active_version_id) REFERENCES template_versions(id) ON DELETE SET NULL;
+
+-- Versions are immutable once written.
+CREATE FUNCTION reject_template_version_update() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'template versions are immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER template_versions_immutable
+    BEFORE UPDATE ON template_versions
+    FOR EACH ROW EXECUTE FUNCTION reject_template_version_update();
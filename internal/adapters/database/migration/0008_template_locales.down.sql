@@ -0,0 +1,15 @@
+-- Rolling back would have to delete every localized template and its
+-- versions, so refuse while any exist; delete them deliberately first.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM templates WHERE locale <> '') THEN
+        RAISE EXCEPTION 'localized templates exist; delete them (DELETE FROM templates WHERE locale <> '''') before rolling back 0008_template_locales';
+    END IF;
+END
+$$;
+
+ALTER TABLE notification_logs DROP COLUMN IF EXISTS locale;
+
+ALTER TABLE templates DROP CONSTRAINT IF EXISTS templates_name_locale_key;
+ALTER TABLE templates ADD CONSTRAINT templates_name_key UNIQUE (name);
+ALTER TABLE templates DROP COLUMN IF EXISTS locale;
@@ -0,0 +1,8 @@
+-- Locale variants: a template is identified by name and locale, where the
+-- empty locale is the default variant used when no better match exists.
+ALTER TABLE templates ADD COLUMN locale TEXT NOT NULL DEFAULT '';
+ALTER TABLE templates DROP CONSTRAINT templates_name_key;
+ALTER TABLE templates ADD CONSTRAINT templates_name_locale_key UNIQUE (name, locale);
+
+-- Record which locale variant was actually sent.
+ALTER TABLE notification_logs ADD COLUMN locale TEXT;
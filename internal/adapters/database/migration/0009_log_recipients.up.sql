@@ -0,0 +1,3 @@
+-- Every address a notification went to, as {"to": [...], "cc": [...],
+-- "bcc": [...], "reply_to": [...]}. recipient keeps the primary To address.
+ALTER TABLE notification_logs ADD COLUMN recipients JSONB NOT NULL DEFAULT '{}';
@@ -0,0 +1,2 @@
+ALTER TABLE notification_logs DROP COLUMN IF EXISTS provider_message_id;
+ALTER TABLE notification_logs DROP COLUMN IF EXISTS provider;
@@ -0,0 +1,4 @@
+-- The email provider that delivered a notification, and the message ID it
+-- assigned, so a delivery can be traced in the provider's own logs.
+ALTER TABLE notification_logs ADD COLUMN provider TEXT;
+ALTER TABLE notification_logs ADD COLUMN provider_message_id TEXT;
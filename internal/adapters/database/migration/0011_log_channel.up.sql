@@ -0,0 +1,3 @@
+-- The channel a notification was sent over; the recipient column holds an
+-- email address or a phone number accordingly.
+ALTER TABLE notification_logs ADD COLUMN channel TEXT NOT NULL DEFAULT 'email';
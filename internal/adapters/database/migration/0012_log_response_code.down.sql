@@ -0,0 +1 @@
+ALTER TABLE notification_logs DROP COLUMN IF EXISTS response_code;
@@ -0,0 +1,2 @@
+-- The HTTP status a webhook receiver answered with, on success or failure.
+ALTER TABLE notification_logs ADD COLUMN response_code INTEGER;
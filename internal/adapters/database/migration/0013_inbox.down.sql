@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS inbox_notifications;
+DROP FUNCTION IF EXISTS notify_inbox_notification();
@@ -0,0 +1,29 @@
+-- Notifications delivered over the "inapp" channel, one row per user.
+CREATE TABLE inbox_notifications (
+    id BIGSERIAL PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    notification_id TEXT NOT NULL,
+    template_name VARCHAR(255) NOT NULL,
+    title TEXT NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    read_at TIMESTAMPTZ
+);
+
+-- A redelivered event must not show up twice in the same inbox.
+CREATE UNIQUE INDEX idx_inbox_notifications_user_notification ON inbox_notifications (user_id, notification_id);
+CREATE INDEX idx_inbox_notifications_user_id ON inbox_notifications (user_id, id DESC);
+CREATE INDEX idx_inbox_notifications_unread ON inbox_notifications (user_id) WHERE read_at IS NULL;
+
+-- Announce new rows so every service instance can push them to the users
+-- subscribed to it. The payload is only the ID, as NOTIFY payloads are small.
+CREATE FUNCTION notify_inbox_notification() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('inbox_notifications', NEW.id::text);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER inbox_notifications_notify
+AFTER INSERT ON inbox_notifications
+FOR EACH ROW EXECUTE FUNCTION notify_inbox_notification();
@@ -0,0 +1,4 @@
+ALTER TABLE template_versions DROP COLUMN IF EXISTS body_inapp;
+ALTER TABLE template_versions DROP COLUMN IF EXISTS body_chat;
+ALTER TABLE template_versions DROP COLUMN IF EXISTS body_webhook;
+ALTER TABLE template_versions DROP COLUMN IF EXISTS body_sms;
@@ -0,0 +1,7 @@
+-- Bodies for the non-email channels, as in the file templates'
+-- body.sms.txt, body.webhook.json, body.chat.json and body.inapp.txt.
+-- An empty body means the version does not support that channel.
+ALTER TABLE template_versions ADD COLUMN body_sms TEXT NOT NULL DEFAULT '';
+ALTER TABLE template_versions ADD COLUMN body_webhook TEXT NOT NULL DEFAULT '';
+ALTER TABLE template_versions ADD COLUMN body_chat TEXT NOT NULL DEFAULT '';
+ALTER TABLE template_versions ADD COLUMN body_inapp TEXT NOT NULL DEFAULT '';
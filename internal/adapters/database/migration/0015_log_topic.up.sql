@@ -0,0 +1,3 @@
+-- The Kafka topic an attempt was consumed from, so a replay goes back to
+-- the same topic and routes even when its template is routed elsewhere too.
+ALTER TABLE notification_logs ADD COLUMN topic TEXT;
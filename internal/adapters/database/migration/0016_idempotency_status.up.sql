@@ -0,0 +1,5 @@
+-- A key is "pending" while an attempt holds a short lease on it, and
+-- "done" once the notification was delivered. Only done keys mark
+-- duplicates; a pending key whose lease ran out, e.g. after a crash, can be
+-- claimed again. Keys stored before this were all taken as delivered.
+ALTER TABLE idempotency_keys ADD COLUMN status TEXT NOT NULL DEFAULT 'done';
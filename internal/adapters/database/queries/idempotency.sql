@@ -0,0 +1,38 @@
+-- name: ClaimIdempotencyKey :one
+-- Inserts the key as pending, or takes over one whose lease or TTL has run
+-- out. Returns no row when the key is still held.
+INSERT INTO idempotency_keys (
+    key,
+    notification_id,
+    status,
+    created_at,
+    expires_at
+) VALUES (
+    $1, $2, 'pending', $3, $4
+)
+ON CONFLICT (key) DO UPDATE
+SET notification_id = EXCLUDED.notification_id,
+    status = EXCLUDED.status,
+    created_at = EXCLUDED.created_at,
+    expires_at = EXCLUDED.expires_at
+WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+RETURNING key;
+
+-- name: GetIdempotencyKeyStatus :one
+SELECT status FROM idempotency_keys
+WHERE key = $1;
+
+-- name: CompleteIdempotencyKey :execrows
+-- Marks a pending key held by the notification as delivered, for the TTL.
+UPDATE idempotency_keys
+SET status = 'done',
+    expires_at = $3
+WHERE key = $1 AND notification_id = $2 AND status = 'pending';
+
+-- name: ReleaseIdempotencyKey :exec
+DELETE FROM idempotency_keys
+WHERE key = $1 AND notification_id = $2 AND status = 'pending';
+
+-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM idempotency_keys
+WHERE expires_at <= $1;
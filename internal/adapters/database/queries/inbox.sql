@@ -0,0 +1,42 @@
+-- name: CreateInboxNotification :one
+-- Returns no row when the notification is already in the user's inbox.
+INSERT INTO inbox_notifications (
+    user_id,
+    notification_id,
+    template_name,
+    title,
+    body,
+    created_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (user_id, notification_id) DO NOTHING
+RETURNING *;
+
+-- name: GetInboxNotification :one
+SELECT * FROM inbox_notifications
+WHERE id = $1;
+
+-- name: ListInboxNotifications :many
+SELECT * FROM inbox_notifications
+WHERE user_id = sqlc.arg('user_id')
+  AND (NOT sqlc.arg('unread_only')::boolean OR read_at IS NULL)
+  AND (sqlc.narg('before_id')::bigint IS NULL OR id < sqlc.narg('before_id'))
+ORDER BY id DESC
+LIMIT sqlc.arg('row_limit');
+
+-- name: CountUnreadInboxNotifications :one
+SELECT COUNT(*) FROM inbox_notifications
+WHERE user_id = $1 AND read_at IS NULL;
+
+-- name: MarkInboxNotificationsRead :execrows
+UPDATE inbox_notifications
+SET read_at = sqlc.arg('read_at')
+WHERE user_id = sqlc.arg('user_id')
+  AND id = ANY(sqlc.arg('ids')::bigint[])
+  AND read_at IS NULL;
+
+-- name: MarkAllInboxNotificationsRead :execrows
+UPDATE inbox_notifications
+SET read_at = $2
+WHERE user_id = $1 AND read_at IS NULL;
@@ -5,7 +5,56 @@ INSERT INTO notification_logs (
     status,
     details,
     data,
-    attempted_at
+    attempted_at,
+    replay_of,
+    notification_id,
+    idempotency_key,
+    locale,
+    recipients,
+    provider,
+    provider_message_id,
+    channel,
+    response_code,
+    topic
 ) VALUES (
-    $1, $2, $3, $4, $5, $6
-);
\ No newline at end of file
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16
+);
+
+-- name: ListFailedNotificationLogs :many
+SELECT * FROM notification_logs
+WHERE status = 'failed'
+  AND replayed_at IS NULL
+  AND (sqlc.narg('template_name')::text IS NULL OR template_name = sqlc.narg('template_name'))
+  AND (sqlc.narg('recipient')::text IS NULL OR recipient = sqlc.narg('recipient'))
+  AND (sqlc.narg('attempted_after')::timestamptz IS NULL OR attempted_at >= sqlc.narg('attempted_after'))
+  AND (sqlc.narg('attempted_before')::timestamptz IS NULL OR attempted_at < sqlc.narg('attempted_before'))
+  AND (cardinality(sqlc.arg('ids')::int[]) = 0 OR id = ANY(sqlc.arg('ids')::int[]))
+ORDER BY id
+LIMIT sqlc.arg('row_limit');
+
+-- name: MarkNotificationLogReplayed :exec
+UPDATE notification_logs
+SET replayed_at = $2
+WHERE id = $1;
+
+-- name: GetNotificationLog :one
+SELECT * FROM notification_logs
+WHERE id = $1;
+
+-- name: GetLatestNotificationLogByNotificationID :one
+SELECT * FROM notification_logs
+WHERE notification_id = $1
+ORDER BY id DESC
+LIMIT 1;
+
+-- name: ListNotificationLogs :many
+SELECT * FROM notification_logs
+WHERE (sqlc.narg('notification_id')::text IS NULL OR notification_id = sqlc.narg('notification_id'))
+  AND (sqlc.narg('recipient')::text IS NULL OR recipient = sqlc.narg('recipient'))
+  AND (sqlc.narg('template_name')::text IS NULL OR template_name = sqlc.narg('template_name'))
+  AND (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status'))
+  AND (sqlc.narg('attempted_after')::timestamptz IS NULL OR attempted_at >= sqlc.narg('attempted_after'))
+  AND (sqlc.narg('attempted_before')::timestamptz IS NULL OR attempted_at < sqlc.narg('attempted_before'))
+  AND (sqlc.narg('before_id')::int IS NULL OR id < sqlc.narg('before_id'))
+ORDER BY id DESC
+LIMIT sqlc.arg('row_limit');
@@ -0,0 +1,79 @@
+-- name: GetActiveTemplateVersion :one
+SELECT t.name, t.locale, v.*
+FROM templates t
+JOIN template_versions v ON v.id = t.active_version_id
+WHERE t.name = $1 AND t.locale = $2;
+
+-- name: CreateTemplate :one
+INSERT INTO templates (name, locale)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: LockTemplateByName :one
+SELECT * FROM templates
+WHERE name = $1 AND locale = $2
+FOR UPDATE;
+
+-- name: CreateTemplateVersion :one
+INSERT INTO template_versions (
+    template_id,
+    version,
+    subject,
+    body_html,
+    body_text,
+    body_sms,
+    body_webhook,
+    body_chat,
+    body_inapp,
+    meta
+) VALUES (
+    sqlc.arg('template_id'),
+    (SELECT COALESCE(MAX(version), 0) + 1 FROM template_versions WHERE template_id = sqlc.arg('template_id')),
+    sqlc.arg('subject'),
+    sqlc.arg('body_html'),
+    sqlc.arg('body_text'),
+    sqlc.arg('body_sms'),
+    sqlc.arg('body_webhook'),
+    sqlc.arg('body_chat'),
+    sqlc.arg('body_inapp'),
+    sqlc.arg('meta')
+)
+RETURNING *;
+
+-- name: GetTemplateVersion :one
+SELECT v.*
+FROM template_versions v
+JOIN templates t ON t.id = v.template_id
+WHERE t.name = $1 AND t.locale = $2 AND v.version = $3;
+
+-- name: SetActiveTemplateVersion :exec
+UPDATE templates
+SET active_version_id = $2,
+    updated_at = NOW()
+WHERE id = $1;
+
+-- name: GetTemplateSummary :one
+SELECT t.name,
+       t.locale,
+       t.created_at,
+       t.updated_at,
+       COALESCE(av.version, 0)::int AS active_version,
+       COALESCE((SELECT MAX(version) FROM template_versions WHERE template_id = t.id), 0)::int AS latest_version
+FROM templates t
+LEFT JOIN template_versions av ON av.id = t.active_version_id
+WHERE t.name = $1 AND t.locale = $2;
+
+-- name: ListTemplateSummaries :many
+SELECT t.name,
+       t.locale,
+       t.created_at,
+       t.updated_at,
+       COALESCE(av.version, 0)::int AS active_version,
+       COALESCE((SELECT MAX(version) FROM template_versions WHERE template_id = t.id), 0)::int AS latest_version
+FROM templates t
+LEFT JOIN template_versions av ON av.id = t.active_version_id
+ORDER BY t.name, t.locale;
+
+-- name: DeleteTemplate :execrows
+DELETE FROM templates
+WHERE name = $1 AND locale = $2;
@@ -0,0 +1,173 @@
+package httpmail
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"time"
+
+	"notification-service/internal/core/repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ProviderHTTP is recorded in notification_logs.provider for emails sent
+// through the JSON API.
+const ProviderHTTP = "http"
+
+// Sender is an EmailSender for SendGrid/SES-style JSON APIs: each email is
+// POSTed as one JSON document, authenticated with a bearer token.
+//
+// A 2xx response means the email was accepted; its ID is read from an "id"
+// or "message_id" field in the body, or the X-Message-Id header. 429 and 5xx
+// responses are worth retrying, 401 and 403 mean the API key was refused,
+// and any other 4xx is a rejection.
+type Sender struct {
+	endpoint string
+	apiKey   string
+	from     string
+	client   *http.Client
+	logger   *logrus.Logger
+}
+
+func NewSender(endpoint, apiKey, from string, timeout time.Duration, logger *logrus.Logger) *Sender {
+	return &Sender{
+		endpoint: endpoint,
+		apiKey:   apiKey,
+		from:     from,
+		client:   &http.Client{Timeout: timeout},
+		logger:   logger,
+	}
+}
+
+type address struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+type attachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	// Content is base64 encoded by encoding/json.
+	Content     []byte `json:"content"`
+	Disposition string `json:"disposition"`
+	ContentID   string `json:"content_id,omitempty"`
+}
+
+type request struct {
+	From        address      `json:"from"`
+	To          []address    `json:"to"`
+	Cc          []address    `json:"cc,omitempty"`
+	Bcc         []address    `json:"bcc,omitempty"`
+	ReplyTo     []address    `json:"reply_to,omitempty"`
+	Subject     string       `json:"subject"`
+	HTML        string       `json:"html"`
+	Text        string       `json:"text,omitempty"`
+	Attachments []attachment `json:"attachments,omitempty"`
+}
+
+type response struct {
+	ID        string `json:"id"`
+	MessageID string `json:"message_id"`
+}
+
+func (s *Sender) Send(ctx context.Context, email *repository.Email) (*repository.SendResult, error) {
+	body, err := s.encode(email)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", repository.ErrEmailRejected, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("could not build email provider request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send email via HTTP provider: %w", err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read email provider response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, repository.NewProviderStatusError("email provider", resp.StatusCode, raw, repository.ErrEmailRejected)
+	}
+
+	result := &repository.SendResult{Provider: ProviderHTTP, MessageID: resp.Header.Get("X-Message-Id")}
+	var decoded response
+	if json.Unmarshal(raw, &decoded) == nil {
+		if decoded.ID != "" {
+			result.MessageID = decoded.ID
+		} else if decoded.MessageID != "" {
+			result.MessageID = decoded.MessageID
+		}
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"recipient":  email.To[0],
+		"message_id": result.MessageID,
+	}).Info("email accepted by HTTP provider")
+	return result, nil
+}
+
+func (s *Sender) encode(email *repository.Email) ([]byte, error) {
+	if len(email.To) == 0 {
+		return nil, fmt.Errorf("email has no recipients")
+	}
+	from := s.from
+	if email.From != "" {
+		from = email.From
+	}
+
+	req := request{Subject: email.Subject, HTML: email.HTML, Text: email.Text}
+	var err error
+	if req.From, err = parseAddress(from); err != nil {
+		return nil, err
+	}
+	for _, list := range []struct {
+		in  []string
+		out *[]address
+	}{
+		{email.To, &req.To}, {email.Cc, &req.Cc}, {email.Bcc, &req.Bcc}, {email.ReplyTo, &req.ReplyTo},
+	} {
+		for _, raw := range list.in {
+			addr, err := parseAddress(raw)
+			if err != nil {
+				return nil, err
+			}
+			*list.out = append(*list.out, addr)
+		}
+	}
+
+	for _, a := range email.Attachments {
+		disposition := "attachment"
+		if a.ContentID != "" {
+			disposition = "inline"
+		}
+		req.Attachments = append(req.Attachments, attachment{
+			Filename:    a.Filename,
+			ContentType: a.ContentType,
+			Content:     a.Content,
+			Disposition: disposition,
+			ContentID:   a.ContentID,
+		})
+	}
+	return json.Marshal(req)
+}
+
+func parseAddress(raw string) (address, error) {
+	addr, err := mail.ParseAddress(raw)
+	if err != nil {
+		return address{}, fmt.Errorf("invalid address %q: %w", raw, err)
+	}
+	return address{Email: addr.Address, Name: addr.Name}, nil
+}
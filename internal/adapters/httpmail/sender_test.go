@@ -0,0 +1,166 @@
+package httpmail
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"notification-service/internal/core/repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+func testEmail() *repository.Email {
+	return &repository.Email{
+		To:      []string{"Admin <admin@example.com>"},
+		Cc:      []string{"ops@example.com"},
+		Subject: "Welcome",
+		HTML:    "<p>Hello</p>",
+		Text:    "Hello",
+		Attachments: []repository.EmailAttachment{
+			{Filename: "logo.png", ContentType: "image/png", Content: []byte("png"), ContentID: "logo"},
+		},
+	}
+}
+
+func newTestSender(url string) *Sender {
+	return NewSender(url, "secret-key", "Service <noreply@example.com>", 5*time.Second, logrus.New())
+}
+
+func TestSendRequest(t *testing.T) {
+	var got request
+	var auth, contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		contentType = r.Header.Get("Content-Type")
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		w.Write([]byte(`{"id": "msg-1"}`))
+	}))
+	defer server.Close()
+
+	if _, err := newTestSender(server.URL).Send(context.Background(), testEmail()); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if auth != "Bearer secret-key" {
+		t.Errorf("Authorization = %q, want bearer token", auth)
+	}
+	if contentType != "application/json" {
+		t.Errorf("Content-Type = %q", contentType)
+	}
+	if got.From != (address{Email: "noreply@example.com", Name: "Service"}) {
+		t.Errorf("from = %+v", got.From)
+	}
+	if len(got.To) != 1 || got.To[0] != (address{Email: "admin@example.com", Name: "Admin"}) {
+		t.Errorf("to = %+v", got.To)
+	}
+	if len(got.Cc) != 1 || got.Cc[0].Email != "ops@example.com" {
+		t.Errorf("cc = %+v", got.Cc)
+	}
+	if got.Subject != "Welcome" || got.HTML != "<p>Hello</p>" || got.Text != "Hello" {
+		t.Errorf("content = %q %q %q", got.Subject, got.HTML, got.Text)
+	}
+	if len(got.Attachments) != 1 {
+		t.Fatalf("attachments = %+v", got.Attachments)
+	}
+	if a := got.Attachments[0]; string(a.Content) != "png" || a.Disposition != "inline" || a.ContentID != "logo" {
+		t.Errorf("attachment = %+v", a)
+	}
+}
+
+func TestSendMessageID(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		body   string
+		want   string
+	}{
+		{"id field", "", `{"id": "a1"}`, "a1"},
+		{"message_id field", "", `{"message_id": "b2"}`, "b2"},
+		{"id wins over message_id", "", `{"id": "a1", "message_id": "b2"}`, "a1"},
+		{"header", "c3", `{}`, "c3"},
+		{"body wins over header", "c3", `{"id": "a1"}`, "a1"},
+		{"no JSON body", "c3", `queued`, "c3"},
+		{"none", "", ``, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.header != "" {
+					w.Header().Set("X-Message-Id", tt.header)
+				}
+				w.WriteHeader(http.StatusAccepted)
+				io.WriteString(w, tt.body)
+			}))
+			defer server.Close()
+
+			result, err := newTestSender(server.URL).Send(context.Background(), testEmail())
+			if err != nil {
+				t.Fatalf("Send: %v", err)
+			}
+			if result.MessageID != tt.want || result.Provider != ProviderHTTP {
+				t.Errorf("result = %+v, want message ID %q", result, tt.want)
+			}
+		})
+	}
+}
+
+func TestSendStatus(t *testing.T) {
+	tests := []struct {
+		status   int
+		rejected bool
+		auth     bool
+	}{
+		{http.StatusOK, false, false},
+		{http.StatusBadRequest, true, false},
+		{http.StatusUnauthorized, false, true},
+		{http.StatusForbidden, false, true},
+		{http.StatusUnprocessableEntity, true, false},
+		{http.StatusRequestTimeout, false, false},
+		{http.StatusTooManyRequests, false, false},
+		{http.StatusInternalServerError, false, false},
+		{http.StatusServiceUnavailable, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				io.WriteString(w, `{"error": "nope"}`)
+			}))
+			defer server.Close()
+
+			_, err := newTestSender(server.URL).Send(context.Background(), testEmail())
+			if tt.status == http.StatusOK {
+				if err != nil {
+					t.Fatalf("Send: %v", err)
+				}
+				return
+			}
+			var statusErr *repository.StatusError
+			if !errors.As(err, &statusErr) || statusErr.StatusCode != tt.status {
+				t.Fatalf("err = %v, want a StatusError with %d", err, tt.status)
+			}
+			if got := errors.Is(err, repository.ErrEmailRejected); got != tt.rejected {
+				t.Errorf("rejected = %v, want %v", got, tt.rejected)
+			}
+			if got := errors.Is(err, repository.ErrProviderAuth); got != tt.auth {
+				t.Errorf("auth failure = %v, want %v", got, tt.auth)
+			}
+		})
+	}
+}
+
+func TestSendInvalidAddress(t *testing.T) {
+	email := testEmail()
+	email.To = []string{"not an address"}
+	_, err := newTestSender("http://127.0.0.1:0").Send(context.Background(), email)
+	if !errors.Is(err, repository.ErrEmailRejected) {
+		t.Errorf("err = %v, want ErrEmailRejected", err)
+	}
+}
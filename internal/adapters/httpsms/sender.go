@@ -0,0 +1,99 @@
+package httpsms
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"notification-service/internal/core/repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ProviderHTTP tells SMS deliveries apart from the email "http" provider
+// in notification_logs.
+const ProviderHTTP = "http-sms"
+
+// Sender is an SMSSender for Twilio-style messaging APIs: each message is
+// POSTed as a form with To, From and Body fields, authenticated with the
+// account ID and token as HTTP basic auth.
+//
+// A 2xx response means the message was accepted; its ID is read from a
+// "sid" or "id" field in the JSON body. Other statuses are classified by
+// repository.StatusError.
+type Sender struct {
+	endpoint  string
+	accountID string
+	authToken string
+	from      string
+	client    *http.Client
+	logger    *logrus.Logger
+}
+
+// NewSender creates a sender posting to endpoint, e.g.
+// https://api.twilio.com/2010-04-01/Accounts/<sid>/Messages.json.
+func NewSender(endpoint, accountID, authToken, from string, timeout time.Duration, logger *logrus.Logger) *Sender {
+	return &Sender{
+		endpoint:  endpoint,
+		accountID: accountID,
+		authToken: authToken,
+		from:      from,
+		client:    &http.Client{Timeout: timeout},
+		logger:    logger,
+	}
+}
+
+type response struct {
+	SID string `json:"sid"`
+	ID  string `json:"id"`
+}
+
+func (s *Sender) Send(ctx context.Context, sms *repository.SMS) (*repository.SendResult, error) {
+	form := url.Values{}
+	form.Set("To", sms.To)
+	form.Set("From", s.from)
+	form.Set("Body", sms.Body)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("could not build sms provider request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(s.accountID, s.authToken)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send sms via HTTP provider: %w", err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sms provider response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, repository.NewProviderStatusError("sms provider", resp.StatusCode, raw, repository.ErrSMSRejected)
+	}
+
+	result := &repository.SendResult{Provider: ProviderHTTP}
+	var decoded response
+	if json.Unmarshal(raw, &decoded) == nil {
+		if decoded.SID != "" {
+			result.MessageID = decoded.SID
+		} else {
+			result.MessageID = decoded.ID
+		}
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"recipient":  sms.To,
+		"message_id": result.MessageID,
+	}).Info("sms accepted by HTTP provider")
+	return result, nil
+}
@@ -0,0 +1,120 @@
+package httpsms
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"notification-service/internal/core/repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+func newTestSender(url string) *Sender {
+	return NewSender(url, "AC123", "token", "+14155550100", 5*time.Second, logrus.New())
+}
+
+func TestSendRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/x-www-form-urlencoded" {
+			t.Errorf("Content-Type = %q", ct)
+		}
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "AC123" || pass != "token" {
+			t.Errorf("basic auth = %q, %q, %v", user, pass, ok)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("parse form: %v", err)
+		}
+		for field, want := range map[string]string{"To": "+14155550123", "From": "+14155550100", "Body": "Hello & welcome"} {
+			if got := r.PostForm.Get(field); got != want {
+				t.Errorf("%s = %q, want %q", field, got, want)
+			}
+		}
+		w.WriteHeader(http.StatusCreated)
+		io.WriteString(w, `{"sid": "SM1"}`)
+	}))
+	defer server.Close()
+
+	result, err := newTestSender(server.URL).Send(context.Background(), &repository.SMS{To: "+14155550123", Body: "Hello & welcome"})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if result.MessageID != "SM1" || result.Provider != ProviderHTTP {
+		t.Errorf("result = %+v", result)
+	}
+}
+
+func TestSendMessageID(t *testing.T) {
+	tests := []struct {
+		body string
+		want string
+	}{
+		{`{"sid": "SM1"}`, "SM1"},
+		{`{"id": "msg-2"}`, "msg-2"},
+		{`{"sid": "SM1", "id": "msg-2"}`, "SM1"},
+		{`accepted`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.body, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				io.WriteString(w, tt.body)
+			}))
+			defer server.Close()
+
+			result, err := newTestSender(server.URL).Send(context.Background(), &repository.SMS{To: "+14155550123", Body: "Hi"})
+			if err != nil {
+				t.Fatalf("Send: %v", err)
+			}
+			if result.MessageID != tt.want {
+				t.Errorf("message ID = %q, want %q", result.MessageID, tt.want)
+			}
+		})
+	}
+}
+
+func TestSendStatus(t *testing.T) {
+	tests := []struct {
+		status   int
+		rejected bool
+		auth     bool
+	}{
+		{http.StatusBadRequest, true, false},
+		{http.StatusUnauthorized, false, true},
+		{http.StatusForbidden, false, true},
+		{http.StatusNotFound, true, false},
+		{http.StatusUnprocessableEntity, true, false},
+		{http.StatusRequestTimeout, false, false},
+		{http.StatusTooManyRequests, false, false},
+		{http.StatusInternalServerError, false, false},
+		{http.StatusBadGateway, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				io.WriteString(w, `{"message": "nope"}`)
+			}))
+			defer server.Close()
+
+			_, err := newTestSender(server.URL).Send(context.Background(), &repository.SMS{To: "+14155550123", Body: "Hi"})
+			var statusErr *repository.StatusError
+			if !errors.As(err, &statusErr) || statusErr.StatusCode != tt.status {
+				t.Fatalf("err = %v, want a StatusError with %d", err, tt.status)
+			}
+			if got := errors.Is(err, repository.ErrSMSRejected); got != tt.rejected {
+				t.Errorf("rejected = %v, want %v", got, tt.rejected)
+			}
+			if got := errors.Is(err, repository.ErrProviderAuth); got != tt.auth {
+				t.Errorf("auth failure = %v, want %v", got, tt.auth)
+			}
+		})
+	}
+}
@@ -2,23 +2,52 @@ package kafka
 
 import (
 	"context"
+	"crypto/sha1"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"notification-service/internal/adapters/routing"
+	"notification-service/internal/core/repository"
 	"notification-service/internal/core/services"
-	"strings"
+	"slices"
+	"strconv"
 	"sync"
+	"time"
 
 	"github.com/IBM/sarama"
 	"github.com/sirupsen/logrus"
 )
 
+// Headers added to dead-lettered messages on top of the original ones.
+const (
+	HeaderOriginalTopic     = "x-original-topic"
+	HeaderOriginalPartition = "x-original-partition"
+	HeaderOriginalOffset    = "x-original-offset"
+	HeaderFailureReason     = "x-failure-reason"
+	HeaderFailureClass      = "x-failure-class"
+	HeaderAttempts          = "x-attempts"
+)
+
+// minDeadLetterBackoff spaces out dead-letter publishes while Kafka is
+// unreachable, even when RETRY_INITIAL_BACKOFF is zero.
+const minDeadLetterBackoff = time.Second
+
 type ConsumerGroupHandler struct {
 	notificationSvc *services.NotificationService
+	routes          *routing.Table
+	dlq             *Producer
+	dlqTopic        string
+	retry           RetryPolicy
 	logger          *logrus.Logger
 }
 
-func NewConsumerGroupHandler(svc *services.NotificationService, logger *logrus.Logger) *ConsumerGroupHandler {
+func NewConsumerGroupHandler(svc *services.NotificationService, routes *routing.Table, dlq *Producer, dlqTopic string, retry RetryPolicy, logger *logrus.Logger) *ConsumerGroupHandler {
 	return &ConsumerGroupHandler{
 		notificationSvc: svc,
+		routes:          routes,
+		dlq:             dlq,
+		dlqTopic:        dlqTopic,
+		retry:           retry,
 		logger:          logger,
 	}
 }
@@ -35,38 +64,220 @@ func (h *ConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession,
 		})
 		log.Info("Kafka message claimed")
 
-		// Derives template name from topic, e.g., "notification.send-welcome" -> "welcome"
-		// This is a simple convention; you might want a more robust mapping.
-		templateName := strings.TrimPrefix(message.Topic, "notification.send-")
+		if !h.handle(session.Context(), message, log) {
+			// The session is shutting down; leave the message unmarked so it is redelivered.
+			return nil
+		}
+		session.MarkMessage(message, "")
+	}
+	return nil
+}
+
+// handle processes one message and reports whether it may be marked as consumed.
+// Messages that cannot be delivered end up on the dead-letter topic.
+func (h *ConsumerGroupHandler) handle(ctx context.Context, message *sarama.ConsumerMessage, log *logrus.Entry) bool {
+	notificationID, ok := headerValue(message, services.HeaderNotificationID)
+	if !ok || notificationID == "" {
+		// Events produced by other services carry no ID. Derive one from the
+		// message's position so every redelivery gets the same ID, which the
+		// inbox and webhook receivers rely on to drop repeats, and keep it
+		// if the message is dead-lettered.
+		notificationID = notificationIDFor(message)
+		message.Headers = append(message.Headers, &sarama.RecordHeader{
+			Key:   []byte(services.HeaderNotificationID),
+			Value: []byte(notificationID),
+		})
+	}
+	log = log.WithField("notification_id", notificationID)
+
+	// The routing table decides which templates and channels serve this topic.
+	if _, ok := h.routes.Lookup(message.Topic); !ok {
+		log.Error("No route configured for topic")
+		return h.deadLetter(ctx, message, errors.New("no route configured for topic"), "permanent", 0, log)
+	}
+
+	var data map[string]interface{}
+	if err := json.Unmarshal(message.Value, &data); err != nil {
+		log.WithError(err).Error("Failed to unmarshal kafka message")
+		return h.deadLetter(ctx, message, err, "permanent", 0, log)
+	}
+
+	// An event goes out over every route of its topic, unless it asks for a
+	// single channel: like the locale, in a header or, from other producers,
+	// the payload.
+	channel, ok := headerValue(message, services.HeaderChannel)
+	if !ok {
+		channel, _ = data["channel"].(string)
+	}
+	routes, _ := h.routes.Deliveries(message.Topic, channel)
 
-		var data map[string]interface{}
-		if err := json.Unmarshal(message.Value, &data); err != nil {
-			log.WithError(err).Error("Failed to unmarshal kafka message, skipping")
-			session.MarkMessage(message, "") // Acknowledge and move on
-			continue
+	req := services.SendRequest{
+		NotificationID: notificationID,
+		Data:           data,
+		Topic:          message.Topic,
+	}
+	if key, ok := headerValue(message, services.HeaderIdempotencyKey); ok && key != "" {
+		req.IdempotencyKey = key
+	} else {
+		// Without the caller's key the message's position identifies it, so
+		// a redelivery after an interrupted fan-out skips the routes that
+		// already went out; keys are scoped per channel, i.e. per route.
+		req.IdempotencyKey = messageRef(message)
+	}
+	// Producers other than the gRPC server may put the locale in the payload.
+	if locale, ok := headerValue(message, services.HeaderLocale); ok {
+		req.Locale = locale
+	} else if locale, ok := data["locale"].(string); ok {
+		req.Locale = locale
+	}
+	if req.Locale != "" {
+		log = log.WithField("locale", req.Locale)
+	}
+	if replayOf, ok := headerValue(message, services.HeaderReplayOf); ok {
+		if id, err := strconv.ParseInt(replayOf, 10, 32); err == nil {
+			req.ReplayOf = int32(id)
+			log = log.WithField("replay_of", id)
 		}
+	}
 
-		recipient, ok := data["email"].(string)
-		if !ok || recipient == "" {
-			log.Error("Recipient 'email' not found or is empty in message, skipping")
-			session.MarkMessage(message, "") // Acknowledge and move on
-			continue
+	for _, route := range routes {
+		deliveryMessage := message
+		if len(routes) > 1 {
+			// A dead-lettered delivery names its channel, so re-driving it
+			// does not repeat the deliveries that succeeded.
+			deliveryMessage = withHeader(message, services.HeaderChannel, route.Channel)
+		}
+		if !h.deliver(ctx, deliveryMessage, route, req, log) {
+			return false
 		}
+	}
+	return true
+}
+
+// deliver sends the event over one route and reports whether handling may
+// continue. Failed deliveries end up on the dead-letter topic.
+func (h *ConsumerGroupHandler) deliver(ctx context.Context, message *sarama.ConsumerMessage, route routing.Route, req services.SendRequest, log *logrus.Entry) bool {
+	log = log.WithFields(logrus.Fields{"template": route.Template, "channel": route.Channel})
+
+	recipientField, ok := repository.RecipientFields[route.Channel]
+	if !ok {
+		log.Error("Unsupported channel in message")
+		return h.deadLetter(ctx, message, fmt.Errorf("unsupported channel %q", route.Channel), "permanent", 0, log)
+	}
+
+	if missing := route.MissingFields(route.Channel, req.Data); len(missing) > 0 {
+		log.WithField("missing", missing).Error("Required fields missing in message")
+		return h.deadLetter(ctx, message, errors.New("missing required fields"), "permanent", 0, log)
+	}
 
-		req := services.SendRequest{
-			To:           recipient,
-			TemplateName: templateName,
-			Data:         data,
+	recipient := route.To
+	if recipient == "" {
+		recipient, _ = req.Data[recipientField].(string)
+	}
+	if recipient == "" {
+		log.Errorf("Recipient '%s' not found or is empty in message", recipientField)
+		return h.deadLetter(ctx, message, fmt.Errorf("recipient '%s' not found or is empty", recipientField), "permanent", 0, log)
+	}
+
+	req.To = recipient
+	req.TemplateName = route.Template
+	req.Channel = route.Channel
+
+	// The service logs every attempt; transient failures are retried here
+	// with backoff until the policy is exhausted.
+	for attempt := 1; ; attempt++ {
+		err := h.notificationSvc.SendNotification(ctx, req)
+		if err == nil {
+			return true
+		}
+		if !services.IsTransient(err) {
+			return h.deadLetter(ctx, message, err, failureClass(err), attempt, log)
+		}
+		if attempt >= h.retry.MaxAttempts {
+			h.notificationSvc.LogExhausted(ctx, req, attempt, err)
+			return h.deadLetter(ctx, message, err, failureClass(err), attempt, log)
 		}
 
-		// The service now handles its own logging, including failures.
-		h.notificationSvc.SendNotification(session.Context(), req)
+		delay := h.retry.Backoff(attempt)
+		log.WithError(err).WithFields(logrus.Fields{"attempt": attempt, "backoff": delay}).Warn("Retrying notification after transient failure")
+		if !sleep(ctx, delay) {
+			return false
+		}
+	}
+}
 
-		// Mark the message as processed regardless of success or failure.
-		// The failure is logged to the DB for later inspection or retry.
-		session.MarkMessage(message, "")
+// deadLetter publishes the original message, its headers and the failure reason
+// to the dead-letter topic. Publishing is retried until it succeeds or the
+// session ends, so an undeliverable message is never silently dropped.
+func (h *ConsumerGroupHandler) deadLetter(ctx context.Context, message *sarama.ConsumerMessage, cause error, class string, attempts int, log *logrus.Entry) bool {
+	headers := make([]sarama.RecordHeader, 0, len(message.Headers)+6)
+	for _, header := range message.Headers {
+		if header != nil {
+			headers = append(headers, *header)
+		}
 	}
-	return nil
+	headers = append(headers,
+		sarama.RecordHeader{Key: []byte(HeaderOriginalTopic), Value: []byte(message.Topic)},
+		sarama.RecordHeader{Key: []byte(HeaderOriginalPartition), Value: []byte(strconv.Itoa(int(message.Partition)))},
+		sarama.RecordHeader{Key: []byte(HeaderOriginalOffset), Value: []byte(strconv.FormatInt(message.Offset, 10))},
+		sarama.RecordHeader{Key: []byte(HeaderFailureReason), Value: []byte(cause.Error())},
+		sarama.RecordHeader{Key: []byte(HeaderFailureClass), Value: []byte(class)},
+		sarama.RecordHeader{Key: []byte(HeaderAttempts), Value: []byte(strconv.Itoa(attempts))},
+	)
+
+	for attempt := 1; ; attempt++ {
+		err := h.dlq.PublishMessage(ctx, h.dlqTopic, message.Key, message.Value, headers)
+		if err == nil {
+			log.WithField("dlq_topic", h.dlqTopic).Warn("Message sent to dead-letter topic")
+			return true
+		}
+		log.WithError(err).WithField("attempt", attempt).Error("Failed to publish to dead-letter topic")
+		if !sleep(ctx, max(h.retry.Backoff(attempt), minDeadLetterBackoff)) {
+			return false
+		}
+	}
+}
+
+// failureClass returns the x-failure-class of a failed send. Errors that do
+// not say whether they are transient are treated as permanent.
+func failureClass(err error) string {
+	var de *services.DeliveryError
+	if errors.As(err, &de) {
+		return de.Class()
+	}
+	return "permanent"
+}
+
+// messageRef identifies a message by its position, which stays the same
+// however often it is redelivered.
+func messageRef(message *sarama.ConsumerMessage) string {
+	return fmt.Sprintf("kafka:%s/%d/%d", message.Topic, message.Partition, message.Offset)
+}
+
+// notificationIDFor derives a name-based UUID from the message's position.
+func notificationIDFor(message *sarama.ConsumerMessage) string {
+	sum := sha1.Sum([]byte(messageRef(message)))
+	sum[6] = sum[6]&0x0f | 0x50 // version 5
+	sum[8] = sum[8]&0x3f | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
+
+// withHeader returns a copy of message with a header added, leaving the
+// original's headers untouched.
+func withHeader(message *sarama.ConsumerMessage, key, value string) *sarama.ConsumerMessage {
+	out := *message
+	out.Headers = append(slices.Clone(message.Headers), &sarama.RecordHeader{Key: []byte(key), Value: []byte(value)})
+	return &out
+}
+
+// headerValue returns the value of the first header with the given key.
+func headerValue(message *sarama.ConsumerMessage, key string) (string, bool) {
+	for _, header := range message.Headers {
+		if header != nil && string(header.Key) == key {
+			return string(header.Value), true
+		}
+	}
+	return "", false
 }
 
 func StartConsumerGroup(ctx context.Context, wg *sync.WaitGroup, brokers, topics []string, groupID string, handler sarama.ConsumerGroupHandler) {
@@ -33,7 +33,8 @@ func NewProducer(brokers []string, logger *logrus.Logger) (*Producer, error) {
 	}, nil
 }
 
-func (p *Producer) Publish(ctx context.Context, topic string, data map[string]interface{}) error {
+// Publish sends data as a JSON message, attaching the given headers.
+func (p *Producer) Publish(ctx context.Context, topic string, data map[string]interface{}, headers map[string]string) error {
 	value, err := json.Marshal(data)
 	if err != nil {
 		return fmt.Errorf("failed to marshal kafka message data: %w", err)
@@ -43,6 +44,35 @@ func (p *Producer) Publish(ctx context.Context, topic string, data map[string]in
 		Topic: topic,
 		Value: sarama.StringEncoder(value),
 	}
+	for key, val := range headers {
+		msg.Headers = append(msg.Headers, sarama.RecordHeader{Key: []byte(key), Value: []byte(val)})
+	}
+
+	partition, offset, err := p.producer.SendMessage(msg)
+	if err != nil {
+		return fmt.Errorf("failed to send message to kafka topic %s: %w", topic, err)
+	}
+
+	p.logger.WithFields(logrus.Fields{
+		"topic":     topic,
+		"partition": partition,
+		"offset":    offset,
+	}).Info("Successfully published message to Kafka")
+
+	return nil
+}
+
+// PublishMessage sends a pre-encoded value with headers, preserving the
+// original bytes exactly. It is used for dead-lettering.
+func (p *Producer) PublishMessage(ctx context.Context, topic string, key, value []byte, headers []sarama.RecordHeader) error {
+	msg := &sarama.ProducerMessage{
+		Topic:   topic,
+		Value:   sarama.ByteEncoder(value),
+		Headers: headers,
+	}
+	if key != nil {
+		msg.Key = sarama.ByteEncoder(key)
+	}
 
 	partition, offset, err := p.producer.SendMessage(msg)
 	if err != nil {
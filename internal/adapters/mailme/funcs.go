@@ -0,0 +1,262 @@
+package mailme
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// currencySymbols covers the currencies our tenants bill in; other codes are
+// printed as a prefix, e.g. "CHF 12.00".
+var currencySymbols = map[string]string{
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+	"INR": "₹",
+	"JPY": "¥",
+}
+
+// zeroDecimalCurrencies have no minor unit.
+var zeroDecimalCurrencies = map[string]bool{"JPY": true, "KRW": true}
+
+// Funcs returns the helper functions available to every subject, HTML and
+// text template. Helpers take the piped value last, so both
+// {{date "2 Jan 2006" .created_at}} and {{.created_at | date "2 Jan 2006"}}
+// work:
+//
+//	date layout value             formats a time, RFC 3339 string or Unix seconds in UTC
+//	dateIn zone layout value      the same in an IANA time zone, e.g. "Europe/Berlin"
+//	number decimals value         1234.5 -> "1,234.50" with decimals=2
+//	currency code value           1234.5 -> "€1,234.50" with code="EUR"
+//	default fallback value        value, or fallback when value is empty
+//	truncate length value         cuts value to length characters, adding "…"
+//	title / upper / lower value   changes the case of value
+//	pluralize one many count      one when count is 1, many otherwise
+//	url base key value ...        base with the query parameters escaped and added
+//	json value                    value as a JSON literal, for webhook payloads
+func Funcs() template.FuncMap {
+	return template.FuncMap{
+		"date":      formatDate,
+		"dateIn":    formatDateIn,
+		"number":    formatNumber,
+		"currency":  formatCurrency,
+		"default":   defaultValue,
+		"truncate":  truncate,
+		"title":     titleCase,
+		"upper":     func(v interface{}) string { return strings.ToUpper(toString(v)) },
+		"lower":     func(v interface{}) string { return strings.ToLower(toString(v)) },
+		"pluralize": pluralize,
+		"url":       buildURL,
+		"json":      toJSON,
+	}
+}
+
+func formatDate(layout string, value interface{}) (string, error) {
+	return formatDateIn("UTC", layout, value)
+}
+
+func formatDateIn(zone, layout string, value interface{}) (string, error) {
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return "", fmt.Errorf("unknown time zone %q", zone)
+	}
+	t, err := toTime(value)
+	if err != nil {
+		return "", err
+	}
+	return t.In(loc).Format(layout), nil
+}
+
+func toTime(value interface{}) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case *time.Time:
+		if v != nil {
+			return *v, nil
+		}
+	case string:
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02"} {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, nil
+			}
+		}
+	default:
+		if seconds, err := toFloat(value); err == nil {
+			whole, frac := math.Modf(seconds)
+			return time.Unix(int64(whole), int64(frac*1e9)).UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("cannot format %v (%T) as a date", value, value)
+}
+
+func formatNumber(decimals int, value interface{}) (string, error) {
+	n, err := toFloat(value)
+	if err != nil {
+		return "", err
+	}
+	return groupThousands(strconv.FormatFloat(n, 'f', decimals, 64)), nil
+}
+
+func formatCurrency(code string, value interface{}) (string, error) {
+	code = strings.ToUpper(code)
+	decimals := 2
+	if zeroDecimalCurrencies[code] {
+		decimals = 0
+	}
+	amount, err := formatNumber(decimals, value)
+	if err != nil {
+		return "", err
+	}
+
+	sign := ""
+	if strings.HasPrefix(amount, "-") {
+		sign, amount = "-", amount[1:]
+	}
+	if symbol, ok := currencySymbols[code]; ok {
+		return sign + symbol + amount, nil
+	}
+	return sign + code + " " + amount, nil
+}
+
+// groupThousands inserts commas into the integer part of a formatted number.
+func groupThousands(formatted string) string {
+	sign := ""
+	if strings.HasPrefix(formatted, "-") {
+		sign, formatted = "-", formatted[1:]
+	}
+	whole, frac, hasFrac := strings.Cut(formatted, ".")
+
+	var b strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(digit)
+	}
+	if hasFrac {
+		b.WriteByte('.')
+		b.WriteString(frac)
+	}
+	return sign + b.String()
+}
+
+func toFloat(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case int:
+		return float64(v), nil
+	case int32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case json.Number:
+		return v.Float64()
+	case string:
+		if n, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+			return n, nil
+		}
+	}
+	return 0, fmt.Errorf("%v (%T) is not a number", value, value)
+}
+
+func defaultValue(fallback, value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return fallback
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return fallback
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			return fallback
+		}
+	case map[string]interface{}:
+		if len(v) == 0 {
+			return fallback
+		}
+	}
+	return value
+}
+
+func truncate(length int, value interface{}) string {
+	s := toString(value)
+	if length < 0 || utf8.RuneCountInString(s) <= length {
+		return s
+	}
+	runes := []rune(s)
+	if length == 0 {
+		return ""
+	}
+	return strings.TrimSpace(string(runes[:length-1])) + "…"
+}
+
+func titleCase(value interface{}) string {
+	return cases.Title(language.Und).String(toString(value))
+}
+
+func pluralize(one, many string, count interface{}) (string, error) {
+	n, err := toFloat(count)
+	if err != nil {
+		return "", err
+	}
+	if n == 1 {
+		return one, nil
+	}
+	return many, nil
+}
+
+// buildURL adds query parameters to an http(s) URL, escaping them properly.
+// Parameters already on the base URL are kept.
+func buildURL(base string, pairs ...interface{}) (string, error) {
+	u, err := url.Parse(base)
+	if err != nil {
+		return "", fmt.Errorf("invalid url %q: %w", base, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("url %q must be http or https", base)
+	}
+	if len(pairs)%2 != 0 {
+		return "", fmt.Errorf("url needs key/value pairs, got %d arguments", len(pairs))
+	}
+
+	query := u.Query()
+	for i := 0; i < len(pairs); i += 2 {
+		query.Add(toString(pairs[i]), toString(pairs[i+1]))
+	}
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+func toJSON(value interface{}) (string, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+func toString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
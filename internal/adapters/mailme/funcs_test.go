@@ -0,0 +1,117 @@
+package mailme
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+)
+
+func TestFuncs(t *testing.T) {
+	tests := []struct {
+		name string
+		tmpl string
+		data map[string]interface{}
+		want string
+	}{
+		{"date from RFC 3339", `{{.t | date "2006-01-02 15:04"}}`, map[string]interface{}{"t": "2024-03-05T10:30:00Z"}, "2024-03-05 10:30"},
+		{"date from day", `{{date "2 Jan 2006" .t}}`, map[string]interface{}{"t": "2024-03-05"}, "5 Mar 2024"},
+		{"date from Unix seconds", `{{.t | date "2006-01-02 15:04"}}`, map[string]interface{}{"t": float64(1700000000)}, "2023-11-14 22:13"},
+		{"date from time.Time", `{{.t | date "15:04"}}`, map[string]interface{}{"t": time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)}, "10:00"},
+		{"dateIn Berlin", `{{.t | dateIn "Europe/Berlin" "15:04 MST"}}`, map[string]interface{}{"t": "2024-01-15T10:00:00Z"}, "11:00 CET"},
+		{"dateIn Kolkata", `{{.t | dateIn "Asia/Kolkata" "15:04"}}`, map[string]interface{}{"t": "2024-01-15T10:00:00Z"}, "15:30"},
+
+		{"number with decimals", `{{.n | number 2}}`, map[string]interface{}{"n": 1234.5}, "1,234.50"},
+		{"number without decimals", `{{.n | number 0}}`, map[string]interface{}{"n": 1234567}, "1,234,567"},
+		{"number negative", `{{.n | number 2}}`, map[string]interface{}{"n": -1234.5}, "-1,234.50"},
+		{"number from string", `{{.n | number 1}}`, map[string]interface{}{"n": " 42 "}, "42.0"},
+		{"number small", `{{.n | number 2}}`, map[string]interface{}{"n": 0.5}, "0.50"},
+
+		{"currency EUR", `{{.n | currency "EUR"}}`, map[string]interface{}{"n": 1234.5}, "€1,234.50"},
+		{"currency lower case code", `{{.n | currency "usd"}}`, map[string]interface{}{"n": 9.99}, "$9.99"},
+		{"currency negative", `{{.n | currency "USD"}}`, map[string]interface{}{"n": -1234.5}, "-$1,234.50"},
+		{"currency JPY has no minor unit", `{{.n | currency "JPY"}}`, map[string]interface{}{"n": 1234.56}, "¥1,235"},
+		{"currency negative JPY", `{{.n | currency "JPY"}}`, map[string]interface{}{"n": -500}, "-¥500"},
+		{"currency without symbol", `{{.n | currency "CHF"}}`, map[string]interface{}{"n": 12}, "CHF 12.00"},
+		{"currency negative without symbol", `{{.n | currency "CHF"}}`, map[string]interface{}{"n": -12}, "-CHF 12.00"},
+
+		{"default for missing", `{{.name | default "there"}}`, map[string]interface{}{}, "there"},
+		{"default for blank", `{{.name | default "there"}}`, map[string]interface{}{"name": "  "}, "there"},
+		{"default for empty list", `{{.items | default "none"}}`, map[string]interface{}{"items": []interface{}{}}, "none"},
+		{"default keeps value", `{{.name | default "there"}}`, map[string]interface{}{"name": "Ann"}, "Ann"},
+		{"default keeps zero", `{{.n | default 5}}`, map[string]interface{}{"n": 0}, "0"},
+
+		{"truncate cuts", `{{.s | truncate 5}}`, map[string]interface{}{"s": "Hello world"}, "Hell…"},
+		{"truncate trims before ellipsis", `{{.s | truncate 7}}`, map[string]interface{}{"s": "Hello world"}, "Hello…"},
+		{"truncate keeps short", `{{.s | truncate 20}}`, map[string]interface{}{"s": "Hello world"}, "Hello world"},
+		{"truncate counts characters", `{{.s | truncate 6}}`, map[string]interface{}{"s": "Grüße aus Berlin"}, "Grüße…"},
+		{"truncate to zero", `{{.s | truncate 0}}`, map[string]interface{}{"s": "Hello"}, ""},
+
+		{"title", `{{.s | title}}`, map[string]interface{}{"s": "acme corp ltd"}, "Acme Corp Ltd"},
+		{"upper", `{{.s | upper}}`, map[string]interface{}{"s": "Acme"}, "ACME"},
+		{"lower", `{{.s | lower}}`, map[string]interface{}{"s": "Acme"}, "acme"},
+		{"upper of number", `{{.n | upper}}`, map[string]interface{}{"n": 1.5}, "1.5"},
+
+		{"pluralize one", `{{.n | pluralize "seat" "seats"}}`, map[string]interface{}{"n": 1}, "seat"},
+		{"pluralize many", `{{.n | pluralize "seat" "seats"}}`, map[string]interface{}{"n": 3.0}, "seats"},
+		{"pluralize zero", `{{pluralize "seat" "seats" .n}}`, map[string]interface{}{"n": 0}, "seats"},
+		{"pluralize from string", `{{.n | pluralize "seat" "seats"}}`, map[string]interface{}{"n": "1"}, "seat"},
+
+		{"url adds escaped parameters", `{{url "https://erp.example.com/setup?ref=mail" "token" .tok}}`, map[string]interface{}{"tok": "a b&c"}, "https://erp.example.com/setup?ref=mail&token=a+b%26c"},
+		{"url without parameters", `{{url "https://erp.example.com/login"}}`, map[string]interface{}{}, "https://erp.example.com/login"},
+
+		{"json string", `{{json .s}}`, map[string]interface{}{"s": `O'Brien "Co"`}, `"O'Brien \"Co\""`},
+		{"json object", `{{json .m}}`, map[string]interface{}{"m": map[string]interface{}{"seats": 3}}, `{"seats":3}`},
+		{"json missing", `{{json .missing}}`, map[string]interface{}{}, "null"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := executeFuncs(tt.tmpl, tt.data)
+			if err != nil {
+				t.Fatalf("execute %s: %v", tt.tmpl, err)
+			}
+			if got != tt.want {
+				t.Errorf("%s = %q, want %q", tt.tmpl, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFuncsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		tmpl string
+		data map[string]interface{}
+		want string
+	}{
+		{"date of non-date", `{{.t | date "2006"}}`, map[string]interface{}{"t": "soon"}, "cannot format"},
+		{"dateIn unknown zone", `{{.t | dateIn "Mars/Olympus" "15:04"}}`, map[string]interface{}{"t": "2024-01-15T10:00:00Z"}, "unknown time zone"},
+		{"number of non-number", `{{.n | number 2}}`, map[string]interface{}{"n": "many"}, "is not a number"},
+		{"currency of non-number", `{{.n | currency "EUR"}}`, map[string]interface{}{"n": "free"}, "is not a number"},
+		{"pluralize of non-number", `{{.n | pluralize "seat" "seats"}}`, map[string]interface{}{"n": "some"}, "is not a number"},
+		{"url with odd parameters", `{{url "https://erp.example.com" "token"}}`, map[string]interface{}{}, "key/value pairs"},
+		{"url with other scheme", `{{url "javascript:alert(1)"}}`, map[string]interface{}{}, "must be http or https"},
+		{"json of unsupported value", `{{json .c}}`, map[string]interface{}{"c": make(chan int)}, "unsupported type"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := executeFuncs(tt.tmpl, tt.data)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("%s error = %v, want one containing %q", tt.tmpl, err, tt.want)
+			}
+		})
+	}
+}
+
+// executeFuncs renders src with text/template, so results are not escaped.
+func executeFuncs(src string, data map[string]interface{}) (string, error) {
+	tmpl, err := template.New("test").Funcs(template.FuncMap(Funcs())).Parse(src)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,385 @@
+package mailme
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// InlineCSS moves the rules of an HTML email's <style> blocks into style
+// attributes, since Gmail and Outlook ignore much of a stylesheet. Simple
+// selectors (type, .class, #id, compounds of these and descendant or child
+// combinations) are inlined, honouring specificity and !important; existing
+// style attributes keep precedence. Rules that cannot be inlined, such as
+// @media queries or :hover, stay behind in a single <style> block, which is
+// removed when nothing is left.
+func InlineCSS(htmlBody string) (string, error) {
+	doc, err := html.Parse(strings.NewReader(htmlBody))
+	if err != nil {
+		return "", err
+	}
+
+	var styles []*html.Node
+	var css strings.Builder
+	walkElements(doc, func(n *html.Node) {
+		if n.DataAtom == atom.Style {
+			styles = append(styles, n)
+			css.WriteString(textContent(n))
+			css.WriteByte('\n')
+		}
+	})
+	if len(styles) == 0 {
+		return htmlBody, nil
+	}
+
+	rules, leftover := parseStylesheet(css.String())
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].specificity < rules[j].specificity
+	})
+
+	walkElements(doc, func(n *html.Node) {
+		applied := newDeclarations()
+		for _, rule := range rules {
+			if rule.selector.matches(n) {
+				applied.merge(rule.declarations)
+			}
+		}
+		if len(applied.order) == 0 {
+			return
+		}
+		// The element's own style attribute beats the stylesheet.
+		applied.merge(parseDeclarations(attr(n, "style")))
+		setAttr(n, "style", applied.String())
+	})
+
+	for i, style := range styles {
+		if i == 0 && strings.TrimSpace(leftover) != "" {
+			style.FirstChild, style.LastChild = nil, nil
+			style.AppendChild(&html.Node{Type: html.TextNode, Data: leftover})
+			continue
+		}
+		style.Parent.RemoveChild(style)
+	}
+
+	var buf bytes.Buffer
+	if err := html.Render(&buf, doc); err != nil {
+		return "", fmt.Errorf("failed to render inlined html: %w", err)
+	}
+	return buf.String(), nil
+}
+
+type cssRule struct {
+	selector     selector
+	specificity  int
+	declarations []declaration
+}
+
+type declaration struct {
+	property  string
+	value     string
+	important bool
+}
+
+// parseStylesheet splits css into inlinable rules, one per selector, and
+// the text of everything that has to stay in a <style> block.
+func parseStylesheet(css string) ([]cssRule, string) {
+	css = stripComments(css)
+
+	var rules []cssRule
+	var leftover strings.Builder
+	for len(css) > 0 {
+		open := strings.IndexByte(css, '{')
+		if open < 0 {
+			break
+		}
+		prelude := strings.TrimSpace(css[:open])
+		end := matchingBrace(css, open)
+		body := css[open+1 : end]
+		raw := css[:min(end+1, len(css))]
+		css = css[min(end+1, len(css)):]
+
+		if strings.HasPrefix(prelude, "@") {
+			// @media, @font-face and friends only work from a stylesheet.
+			leftover.WriteString(strings.TrimSpace(raw) + "\n")
+			continue
+		}
+
+		declarations := parseDeclarations(body)
+		var kept []string
+		for _, text := range strings.Split(prelude, ",") {
+			text = strings.TrimSpace(text)
+			sel, ok := parseSelector(text)
+			if !ok {
+				kept = append(kept, text)
+				continue
+			}
+			rules = append(rules, cssRule{selector: sel, specificity: sel.specificity(), declarations: declarations})
+		}
+		if len(kept) > 0 {
+			leftover.WriteString(strings.Join(kept, ", ") + " {" + body + "}\n")
+		}
+	}
+	return rules, leftover.String()
+}
+
+func parseDeclarations(text string) []declaration {
+	var out []declaration
+	for _, part := range strings.Split(text, ";") {
+		property, value, ok := strings.Cut(part, ":")
+		if !ok {
+			continue
+		}
+		property = strings.ToLower(strings.TrimSpace(property))
+		value = strings.TrimSpace(value)
+		important := false
+		if i := strings.Index(strings.ToLower(value), "!important"); i >= 0 {
+			important = true
+			value = strings.TrimSpace(value[:i])
+		}
+		if property == "" || value == "" {
+			continue
+		}
+		out = append(out, declaration{property: property, value: value, important: important})
+	}
+	return out
+}
+
+// declarations is an ordered set of CSS declarations keyed by property.
+type declarations struct {
+	values map[string]declaration
+	order  []string
+}
+
+func newDeclarations() *declarations {
+	return &declarations{values: make(map[string]declaration)}
+}
+
+// merge applies later declarations over earlier ones, except that an
+// !important value is only replaced by another !important one.
+func (d *declarations) merge(decls []declaration) {
+	for _, decl := range decls {
+		existing, ok := d.values[decl.property]
+		if ok && existing.important && !decl.important {
+			continue
+		}
+		if !ok {
+			d.order = append(d.order, decl.property)
+		}
+		d.values[decl.property] = decl
+	}
+}
+
+func (d *declarations) String() string {
+	parts := make([]string, 0, len(d.order))
+	for _, property := range d.order {
+		decl := d.values[property]
+		value := decl.value
+		if decl.important {
+			value += " !important"
+		}
+		parts = append(parts, property+": "+value)
+	}
+	return strings.Join(parts, "; ") + ";"
+}
+
+// selector is a chain of compound selectors joined by combinators, stored
+// right to left: parts[0] is the element being styled.
+type selector struct {
+	parts       []compound
+	combinators []byte // combinators[i] joins parts[i] to parts[i+1]: ' ' or '>'
+}
+
+type compound struct {
+	tag     string
+	id      string
+	classes []string
+}
+
+// parseSelector parses the selector subset that can be inlined. It reports
+// false for anything else, e.g. pseudo-classes, attributes or siblings.
+func parseSelector(text string) (selector, bool) {
+	if text == "" || strings.ContainsAny(text, ":[*+~") {
+		return selector{}, false
+	}
+	text = strings.ReplaceAll(text, ">", " > ")
+
+	var sel selector
+	combinator := byte(' ')
+	fields := strings.Fields(text)
+	for i := len(fields) - 1; i >= 0; i-- {
+		field := fields[i]
+		if field == ">" {
+			if len(sel.parts) == 0 || combinator == '>' {
+				return selector{}, false
+			}
+			combinator = '>'
+			continue
+		}
+		c, ok := parseCompound(field)
+		if !ok {
+			return selector{}, false
+		}
+		if len(sel.parts) > 0 {
+			sel.combinators = append(sel.combinators, combinator)
+		}
+		sel.parts = append(sel.parts, c)
+		combinator = ' '
+	}
+	if len(sel.parts) == 0 || combinator == '>' {
+		return selector{}, false
+	}
+	return sel, true
+}
+
+func parseCompound(text string) (compound, bool) {
+	var c compound
+	for len(text) > 0 {
+		end := strings.IndexAny(text[1:], ".#") + 1
+		if end == 0 {
+			end = len(text)
+		}
+		token := text[:end]
+		text = text[end:]
+		switch token[0] {
+		case '.':
+			if len(token) == 1 {
+				return compound{}, false
+			}
+			c.classes = append(c.classes, token[1:])
+		case '#':
+			if len(token) == 1 || c.id != "" {
+				return compound{}, false
+			}
+			c.id = token[1:]
+		default:
+			if c.tag != "" || c.id != "" || len(c.classes) > 0 {
+				return compound{}, false
+			}
+			c.tag = strings.ToLower(token)
+		}
+	}
+	return c, true
+}
+
+// specificity packs the (id, class, type) counts into one comparable number.
+func (s selector) specificity() int {
+	ids, classes, tags := 0, 0, 0
+	for _, part := range s.parts {
+		if part.id != "" {
+			ids++
+		}
+		classes += len(part.classes)
+		if part.tag != "" {
+			tags++
+		}
+	}
+	return ids*10000 + classes*100 + tags
+}
+
+func (s selector) matches(n *html.Node) bool {
+	return s.matchFrom(0, n)
+}
+
+func (s selector) matchFrom(i int, n *html.Node) bool {
+	if !s.parts[i].matches(n) {
+		return false
+	}
+	if i == len(s.parts)-1 {
+		return true
+	}
+	for parent := n.Parent; parent != nil && parent.Type == html.ElementNode; parent = parent.Parent {
+		if s.matchFrom(i+1, parent) {
+			return true
+		}
+		if s.combinators[i] == '>' {
+			return false
+		}
+	}
+	return false
+}
+
+func (c compound) matches(n *html.Node) bool {
+	if n.Type != html.ElementNode {
+		return false
+	}
+	if c.tag != "" && c.tag != n.Data {
+		return false
+	}
+	if c.id != "" && c.id != attr(n, "id") {
+		return false
+	}
+	classes := strings.Fields(attr(n, "class"))
+	for _, want := range c.classes {
+		found := false
+		for _, class := range classes {
+			if class == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func walkElements(n *html.Node, fn func(*html.Node)) {
+	if n.Type == html.ElementNode {
+		fn(n)
+	}
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling
+		walkElements(c, fn)
+		c = next
+	}
+}
+
+func setAttr(n *html.Node, key, value string) {
+	for i := range n.Attr {
+		if n.Attr[i].Key == key {
+			n.Attr[i].Val = value
+			return
+		}
+	}
+	n.Attr = append(n.Attr, html.Attribute{Key: key, Val: value})
+}
+
+func stripComments(css string) string {
+	var b strings.Builder
+	for {
+		start := strings.Index(css, "/*")
+		if start < 0 {
+			b.WriteString(css)
+			return b.String()
+		}
+		b.WriteString(css[:start])
+		end := strings.Index(css[start+2:], "*/")
+		if end < 0 {
+			return b.String()
+		}
+		css = css[start+2+end+2:]
+	}
+}
+
+// matchingBrace returns the index of the brace closing the one at open, or
+// len(css) if it is unterminated.
+func matchingBrace(css string, open int) int {
+	depth := 0
+	for i := open; i < len(css); i++ {
+		switch css[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(css)
+}
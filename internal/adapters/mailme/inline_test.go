@@ -0,0 +1,130 @@
+package mailme
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestInlineCSS(t *testing.T) {
+	tests := []struct {
+		name  string
+		css   string
+		body  string
+		want  string // style attribute of the element with id "t"
+		style string // what is left of the <style> block, "" if removed
+	}{
+		{
+			name: "type selector",
+			css:  "p { color: red; margin: 0 }",
+			body: `<p id="t">x</p>`,
+			want: "color: red; margin: 0;",
+		},
+		{
+			name: "class beats type regardless of order",
+			css:  ".note { color: blue } p { color: red }",
+			body: `<p id="t" class="note">x</p>`,
+			want: "color: blue;",
+		},
+		{
+			name: "id beats class",
+			css:  "#t { color: green } p.note { color: blue }",
+			body: `<p id="t" class="note">x</p>`,
+			want: "color: green;",
+		},
+		{
+			name: "later rule wins at equal specificity",
+			css:  "p { color: red } p { color: blue }",
+			body: `<p id="t">x</p>`,
+			want: "color: blue;",
+		},
+		{
+			name: "important beats higher specificity",
+			css:  "p { color: red !important } #t { color: blue }",
+			body: `<p id="t">x</p>`,
+			want: "color: red !important;",
+		},
+		{
+			name: "style attribute beats the stylesheet",
+			css:  "p { color: red; margin: 0 }",
+			body: `<p id="t" style="color: blue">x</p>`,
+			want: "color: blue; margin: 0;",
+		},
+		{
+			name: "important stylesheet rule beats the style attribute",
+			css:  "p { color: red !important }",
+			body: `<p id="t" style="color: blue">x</p>`,
+			want: "color: red !important;",
+		},
+		{
+			name: "important style attribute beats important rule",
+			css:  "p { color: red !important }",
+			body: `<p id="t" style="color: blue !important">x</p>`,
+			want: "color: blue !important;",
+		},
+		{
+			name: "descendant selector",
+			css:  "td a { color: red }",
+			body: `<table><tr><td><span><a id="t" href="#">x</a></span></td></tr></table>`,
+			want: "color: red;",
+		},
+		{
+			name: "child selector does not match grandchildren",
+			css:  ".card > a { color: red } a { margin: 0 }",
+			body: `<div class="card"><span><a id="t" href="#">x</a></span></div>`,
+			want: "margin: 0;",
+		},
+		{
+			name: "selector lists and comments",
+			css:  "/* brand */ h1, .title { font-weight: bold }",
+			body: `<p id="t" class="title">x</p>`,
+			want: "font-weight: bold;",
+		},
+		{
+			name:  "media queries and pseudo-classes are left behind",
+			css:   "p { color: red } @media (max-width: 600px) { p { color: blue } } a:hover, p.x { text-decoration: none }",
+			body:  `<p id="t" class="x">x</p>`,
+			want:  "color: red; text-decoration: none;",
+			style: "@media (max-width: 600px) { p { color: blue } }\na:hover { text-decoration: none }\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := InlineCSS("<html><head><style>" + tt.css + "</style></head><body>" + tt.body + "</body></html>")
+			if err != nil {
+				t.Fatalf("InlineCSS() error = %v", err)
+			}
+			doc, err := html.Parse(strings.NewReader(out))
+			if err != nil {
+				t.Fatalf("output does not parse: %v", err)
+			}
+			var style, leftover string
+			walkElements(doc, func(n *html.Node) {
+				if attr(n, "id") == "t" {
+					style = attr(n, "style")
+				}
+				if n.Data == "style" {
+					leftover = textContent(n)
+				}
+			})
+			if style != tt.want {
+				t.Errorf("style = %q, want %q", style, tt.want)
+			}
+			if leftover != tt.style {
+				t.Errorf("<style> = %q, want %q", leftover, tt.style)
+			}
+		})
+	}
+}
+
+func TestInlineCSSWithoutStyleBlock(t *testing.T) {
+	body := `<p style="color: red">unchanged</p>`
+	out, err := InlineCSS(body)
+	if err != nil {
+		t.Fatalf("InlineCSS() error = %v", err)
+	}
+	if out != body {
+		t.Errorf("InlineCSS() = %q, want input unchanged", out)
+	}
+}
@@ -1,15 +1,29 @@
 package mailme
 
 import (
-	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
-	"html/template"
+	"io"
+	"mime"
+	"net/mail"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"notification-service/internal/core/repository"
 
 	"github.com/sirupsen/logrus"
 	"gopkg.in/gomail.v2"
 )
 
-// Mailer sends templated emails using gomail.
+// ProviderSMTP is the provider name Mailer reports in send results.
+const ProviderSMTP = "smtp"
+
+// Mailer is the SMTP EmailSender, sending through gomail.
 type Mailer struct {
 	From   string
 	Host   string
@@ -17,6 +31,14 @@ type Mailer struct {
 	User   string
 	Pass   string
 	Logger *logrus.Logger
+	// PoolSize caps the number of SMTP connections kept open for reuse, and
+	// IdleTimeout closes those unused for longer. Both must be set before
+	// the first email is sent; zero selects the defaults.
+	PoolSize    int
+	IdleTimeout time.Duration
+
+	poolOnce sync.Once
+	pool     *connPool
 }
 
 // NewMailer creates a new instance of the Mailer.
@@ -31,62 +53,144 @@ func NewMailer(host string, port int, user, pass, from string, logger *logrus.Lo
 	}
 }
 
-// Mail parses and sends a multipart email with both HTML and plain text parts.
-func (m *Mailer) Mail(to, subjectTemplate, htmlTemplate, textTemplate string, data map[string]interface{}) error {
-	log := m.Logger.WithFields(logrus.Fields{"recipient": to})
+// Send builds a multipart email with both HTML and plain text parts, plus
+// any attachments, and sends it over a pooled SMTP connection.
+func (m *Mailer) Send(ctx context.Context, email *repository.Email) (*repository.SendResult, error) {
+	if len(email.To) == 0 {
+		return nil, fmt.Errorf("%w: email has no recipients", repository.ErrEmailRejected)
+	}
+	log := m.Logger.WithFields(logrus.Fields{
+		"recipient":   email.To[0],
+		"recipients":  len(email.To) + len(email.Cc) + len(email.Bcc),
+		"attachments": len(email.Attachments),
+	})
 	log.Info("preparing to send email")
 
-	// 1. Render Subject
-	subject, err := m.render("subject", subjectTemplate, data)
-	if err != nil {
-		return fmt.Errorf("failed to render subject template: %w", err)
+	// Create Message
+	msg := gomail.NewMessage()
+	from := m.From
+	if email.From != "" {
+		from = email.From
+	}
+	messageID := newMessageID(from)
+	msg.SetHeader("Message-ID", messageID)
+	msg.SetHeader("From", from)
+	msg.SetHeader("To", email.To...)
+	if len(email.Cc) > 0 {
+		msg.SetHeader("Cc", email.Cc...)
+	}
+	if len(email.Bcc) > 0 {
+		// gomail sends to Bcc addresses but leaves the header out of the message.
+		msg.SetHeader("Bcc", email.Bcc...)
+	}
+	if len(email.ReplyTo) > 0 {
+		msg.SetHeader("Reply-To", email.ReplyTo...)
 	}
+	msg.SetHeader("Subject", email.Subject)
+	msg.SetBody("text/html", email.HTML)
 
-	// 2. Render HTML Body
-	htmlBody, err := m.render("html", htmlTemplate, data)
-	if err != nil {
-		return fmt.Errorf("failed to render html template: %w", err)
+	// Add Plain Text Body
+	if email.Text != "" {
+		msg.AddAlternative("text/plain", email.Text)
 	}
 
-	// 3. Create Message
-	msg := gomail.NewMessage()
-	msg.SetHeader("From", m.From)
-	msg.SetHeader("To", to)
-	msg.SetHeader("Subject", subject)
-	msg.SetBody("text/html", htmlBody)
-
-	// 4. Render and add Plain Text Body (optional)
-	if textTemplate != "" {
-		textBody, err := m.render("text", textTemplate, data)
-		if err != nil {
-			// Log a warning but don't fail the entire send, as HTML is the primary part.
-			log.WithError(err).Warn("failed to render plain text template, sending HTML only")
-		} else {
-			msg.AddAlternative("text/plain", textBody)
-		}
+	for _, attachment := range email.Attachments {
+		attach(msg, attachment)
 	}
 
-	// 5. Dial and Send
-	dialer := gomail.NewDialer(m.Host, m.Port, m.User, m.Pass)
-	if err := dialer.DialAndSend(msg); err != nil {
-		return fmt.Errorf("failed to send email via SMTP: %w", err)
+	// Send over a pooled connection
+	from, to, err := envelope(msg)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", repository.ErrEmailRejected, err)
 	}
+	if err := m.connections().send(from, to, msg); err != nil {
+		if isAuthReply(err) && !errors.Is(err, repository.ErrProviderAuth) {
+			err = fmt.Errorf("%w: %w", repository.ErrProviderAuth, err)
+		}
+		return nil, fmt.Errorf("failed to send email via SMTP: %w", err)
+	}
+
+	log.WithField("message_id", messageID).Info("email sent successfully")
+	return &repository.SendResult{Provider: ProviderSMTP, MessageID: messageID}, nil
+}
 
-	log.Info("email sent successfully")
-	return nil
+// Close closes the pooled SMTP connections.
+func (m *Mailer) Close() error {
+	return m.connections().close()
 }
 
-// render is a helper function to parse and execute a template string.
-func (m *Mailer) render(templateName, templateStr string, data map[string]interface{}) (string, error) {
-	tmpl, err := template.New(templateName).Parse(templateStr)
+func (m *Mailer) connections() *connPool {
+	m.poolOnce.Do(func() {
+		m.pool = newConnPool(newSMTPDialer(m.Host, m.Port, m.User, m.Pass), m.PoolSize, m.IdleTimeout)
+	})
+	return m.pool
+}
+
+func attach(msg *gomail.Message, a repository.EmailAttachment) {
+	mediaType, params, err := mime.ParseMediaType(a.ContentType)
 	if err != nil {
-		return "", err
+		mediaType, params = "application/octet-stream", map[string]string{}
+	}
+	params["name"] = a.Filename
+
+	header := map[string][]string{"Content-Type": {mime.FormatMediaType(mediaType, params)}}
+	content := a.Content
+	settings := []gomail.FileSetting{
+		gomail.SetCopyFunc(func(w io.Writer) error {
+			_, err := w.Write(content)
+			return err
+		}),
+	}
+	if a.ContentID != "" {
+		header["Content-ID"] = []string{"<" + a.ContentID + ">"}
+		msg.Embed(a.Filename, append(settings, gomail.SetHeader(header))...)
+		return
+	}
+	msg.Attach(a.Filename, append(settings, gomail.SetHeader(header))...)
+}
+
+// newMessageID returns a unique Message-ID in the sender's domain.
+func newMessageID(from string) string {
+	domain := "localhost"
+	if addr, err := mail.ParseAddress(from); err == nil {
+		if at := strings.LastIndexByte(addr.Address, '@'); at >= 0 {
+			domain = addr.Address[at+1:]
+		}
+	}
+	var random [16]byte
+	if _, err := rand.Read(random[:]); err != nil {
+		// Only fails if crypto/rand does; the timestamp alone is still unique enough.
+		return fmt.Sprintf("<%d@%s>", time.Now().UnixNano(), domain)
 	}
+	return "<" + hex.EncodeToString(random[:]) + "@" + domain + ">"
+}
 
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, data); err != nil {
-		return "", err
+// envelope returns the SMTP envelope of msg: the Sender or From address,
+// and every To, Cc and Bcc address once.
+func envelope(msg *gomail.Message) (string, []string, error) {
+	fromHeader := msg.GetHeader("Sender")
+	if len(fromHeader) == 0 {
+		fromHeader = msg.GetHeader("From")
+	}
+	if len(fromHeader) == 0 {
+		return "", nil, errors.New("message has no From header")
+	}
+	from, err := mail.ParseAddress(fromHeader[0])
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid from address %q: %v", fromHeader[0], err)
 	}
 
-	return buf.String(), nil
+	var to []string
+	for _, field := range []string{"To", "Cc", "Bcc"} {
+		for _, value := range msg.GetHeader(field) {
+			addr, err := mail.ParseAddress(value)
+			if err != nil {
+				return "", nil, fmt.Errorf("invalid %s address %q: %v", strings.ToLower(field), value, err)
+			}
+			if !slices.Contains(to, addr.Address) {
+				to = append(to, addr.Address)
+			}
+		}
+	}
+	return from.Address, to, nil
 }
@@ -0,0 +1,215 @@
+package mailme
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// PlainText derives a readable text/plain version of an HTML email body, for
+// templates that have no body.txt. Headings are underlined, list items are
+// bulleted or numbered, and link targets are listed as numbered footnotes.
+func PlainText(htmlBody string) string {
+	doc, err := html.Parse(strings.NewReader(htmlBody))
+	if err != nil {
+		// html.Parse only fails on reader errors, which a string cannot produce.
+		return ""
+	}
+
+	w := &textWriter{}
+	w.walk(doc)
+
+	text := strings.TrimSpace(w.buf.String())
+	if len(w.links) > 0 {
+		var footnotes strings.Builder
+		for i, link := range w.links {
+			fmt.Fprintf(&footnotes, "\n[%d] %s", i+1, link)
+		}
+		text += "\n\n" + strings.TrimPrefix(footnotes.String(), "\n")
+	}
+	return text + "\n"
+}
+
+// textWriter accumulates plain text, collapsing whitespace the way a browser
+// would and keeping track of line breaks between blocks.
+type textWriter struct {
+	buf      strings.Builder
+	newlines int  // newlines at the end of buf
+	space    bool // whitespace seen since the last word
+	lists    []int
+	links    []string
+}
+
+func (w *textWriter) walk(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		w.text(n.Data)
+		return
+	case html.ElementNode:
+	default:
+		w.children(n)
+		return
+	}
+
+	switch n.DataAtom {
+	case atom.Head, atom.Style, atom.Script, atom.Title:
+		// Not part of the visible message.
+	case atom.Br:
+		w.write("\n")
+	case atom.Hr:
+		w.block(2)
+		w.write(strings.Repeat("-", 40))
+		w.block(2)
+	case atom.H1, atom.H2:
+		underline := "="
+		if n.DataAtom == atom.H2 {
+			underline = "-"
+		}
+		w.block(2)
+		heading := collapse(textContent(n))
+		w.write(heading)
+		w.write("\n" + strings.Repeat(underline, len([]rune(heading))))
+		w.block(2)
+	case atom.H3, atom.H4, atom.H5, atom.H6, atom.P, atom.Blockquote, atom.Table:
+		w.block(2)
+		w.children(n)
+		w.block(2)
+	case atom.Ul, atom.Ol:
+		w.block(1)
+		w.lists = append(w.lists, 0)
+		if n.DataAtom == atom.Ul {
+			w.lists[len(w.lists)-1] = -1
+		}
+		w.children(n)
+		w.lists = w.lists[:len(w.lists)-1]
+		w.block(1)
+	case atom.Li:
+		w.block(1)
+		w.write(w.bullet())
+		w.children(n)
+		w.block(1)
+	case atom.Div, atom.Tr, atom.Section, atom.Header, atom.Footer:
+		w.block(1)
+		w.children(n)
+		w.block(1)
+	case atom.Td, atom.Th:
+		w.space = true
+		w.children(n)
+		w.space = true
+	case atom.Img:
+		if alt := attr(n, "alt"); alt != "" {
+			w.text(alt)
+		}
+	case atom.A:
+		w.children(n)
+		w.link(n)
+	default:
+		w.children(n)
+	}
+}
+
+func (w *textWriter) children(n *html.Node) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		w.walk(c)
+	}
+}
+
+// link adds a footnote for an anchor, unless its text already shows the target.
+func (w *textWriter) link(n *html.Node) {
+	href := strings.TrimSpace(attr(n, "href"))
+	if href == "" || strings.HasPrefix(href, "#") {
+		return
+	}
+	label := collapse(textContent(n))
+	if label == href || "mailto:"+label == href {
+		return
+	}
+	w.links = append(w.links, strings.TrimPrefix(href, "mailto:"))
+	w.write(fmt.Sprintf(" [%d]", len(w.links)))
+}
+
+// bullet returns the marker for the next item of the innermost list.
+func (w *textWriter) bullet() string {
+	if len(w.lists) == 0 {
+		return "* "
+	}
+	indent := strings.Repeat("  ", len(w.lists)-1)
+	top := len(w.lists) - 1
+	if w.lists[top] < 0 {
+		return indent + "* "
+	}
+	w.lists[top]++
+	return indent + strconv.Itoa(w.lists[top]) + ". "
+}
+
+func (w *textWriter) text(s string) {
+	words := strings.Fields(s)
+	if len(words) == 0 {
+		if s != "" {
+			w.space = true
+		}
+		return
+	}
+	if isSpace(s[0]) {
+		w.space = true
+	}
+	w.write(strings.Join(words, " "))
+	w.space = isSpace(s[len(s)-1])
+}
+
+// write appends s, emitting any pending inter-word space first.
+func (w *textWriter) write(s string) {
+	if w.space && w.newlines == 0 && w.buf.Len() > 0 && !strings.HasPrefix(s, "\n") {
+		w.buf.WriteByte(' ')
+	}
+	w.space = false
+	w.buf.WriteString(s)
+	if trimmed := strings.TrimRight(s, "\n"); trimmed == "" {
+		w.newlines += len(s)
+	} else {
+		w.newlines = len(s) - len(trimmed)
+	}
+}
+
+// block ensures the output ends with at least n line breaks, so the next
+// block starts on a fresh line (n=1) or after a blank line (n=2).
+func (w *textWriter) block(n int) {
+	w.space = false
+	if w.buf.Len() == 0 {
+		return
+	}
+	for ; w.newlines < n; w.newlines++ {
+		w.buf.WriteByte('\n')
+	}
+}
+
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var b strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		b.WriteString(textContent(c))
+	}
+	return b.String()
+}
+
+func collapse(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
@@ -0,0 +1,74 @@
+package mailme
+
+import "testing"
+
+func TestPlainText(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "paragraphs collapse whitespace",
+			html: "<p>Hello\n   <b>there</b>,</p><p>second  paragraph</p>",
+			want: "Hello there,\n\nsecond paragraph\n",
+		},
+		{
+			name: "line breaks",
+			html: "<p>one<br>two<br/>three</p>",
+			want: "one\ntwo\nthree\n",
+		},
+		{
+			name: "headings are underlined",
+			html: "<h1>Welcome</h1><h2>Next steps</h2><h3>Details</h3><p>Body</p>",
+			want: "Welcome\n=======\n\nNext steps\n----------\n\nDetails\n\nBody\n",
+		},
+		{
+			name: "unordered list",
+			html: "<p>Includes:</p><ul><li>Reports</li><li>Invoices</li></ul>",
+			want: "Includes:\n\n* Reports\n* Invoices\n",
+		},
+		{
+			name: "ordered list with nested list",
+			html: "<ol><li>Sign in<ul><li>Use SSO</li></ul></li><li>Invite users</li></ol>",
+			want: "1. Sign in\n  * Use SSO\n2. Invite users\n",
+		},
+		{
+			name: "links become footnotes",
+			html: `<p>Open <a href="https://erp.example.com/login">the login page</a> or <a href="https://erp.example.com/help">help</a>.</p>`,
+			want: "Open the login page [1] or help [2].\n\n[1] https://erp.example.com/login\n[2] https://erp.example.com/help\n",
+		},
+		{
+			name: "links showing their target get no footnote",
+			html: `<p><a href="https://erp.example.com">https://erp.example.com</a> <a href="mailto:help@example.com">help@example.com</a></p>`,
+			want: "https://erp.example.com help@example.com\n",
+		},
+		{
+			name: "mailto footnote drops the scheme and fragments are skipped",
+			html: `<p><a href="mailto:help@example.com">Support</a> <a href="#top">Top</a></p>`,
+			want: "Support [1] Top\n\n[1] help@example.com\n",
+		},
+		{
+			name: "head, style and script are dropped",
+			html: "<html><head><title>Subject</title><style>p { color: red; }</style></head><body><script>x()</script><p>Visible</p></body></html>",
+			want: "Visible\n",
+		},
+		{
+			name: "table cells are separated by spaces",
+			html: "<table><tr><td>Plan</td><td>Pro</td></tr><tr><td>Seats</td><td>10</td></tr></table>",
+			want: "Plan Pro\nSeats 10\n",
+		},
+		{
+			name: "images use their alt text and rules a line",
+			html: `<p><img src="logo.png" alt="ERP"> news</p><hr><p>Footer</p>`,
+			want: "ERP news\n\n----------------------------------------\n\nFooter\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PlainText(tt.html); got != tt.want {
+				t.Errorf("PlainText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package mailme
+
+import (
+	"errors"
+	"io"
+	"net/smtp"
+	"net/textproto"
+	"sync"
+	"time"
+)
+
+// Defaults used when the Mailer's pool settings are left at zero.
+const (
+	defaultPoolSize    = 4
+	defaultIdleTimeout = 30 * time.Second
+)
+
+// errPoolClosed is returned for sends attempted after Close.
+var errPoolClosed = errors.New("smtp connection pool is closed")
+
+// connPool keeps authenticated SMTP connections open between emails, so a
+// send does not pay for the TCP, TLS and AUTH handshakes each time. At most
+// size connections are open at once; callers beyond that wait for one.
+//
+// An idle connection is checked with NOOP before it is reused, and a
+// background reaper closes connections idle for longer than the timeout,
+// so the server is not left holding them until the next email.
+type connPool struct {
+	dialer      *smtpDialer
+	idleTimeout time.Duration
+	slots       chan struct{}
+	done        chan struct{}
+
+	mu     sync.Mutex
+	idle   []*pooledConn // most recently used last
+	closed bool
+}
+
+type pooledConn struct {
+	client   *smtp.Client
+	lastUsed time.Time
+}
+
+func newConnPool(dialer *smtpDialer, size int, idleTimeout time.Duration) *connPool {
+	if size <= 0 {
+		size = defaultPoolSize
+	}
+	if idleTimeout <= 0 {
+		idleTimeout = defaultIdleTimeout
+	}
+	p := &connPool{
+		dialer:      dialer,
+		idleTimeout: idleTimeout,
+		slots:       make(chan struct{}, size),
+		done:        make(chan struct{}),
+	}
+	go p.reap()
+	return p
+}
+
+// send delivers msg over a pooled connection. If a reused connection fails
+// without an SMTP reply, the server most likely dropped it after the health
+// check, so the message is retried once on a fresh connection. After an
+// SMTP reply the connection is reset and kept, as only the message failed.
+func (p *connPool) send(from string, to []string, msg io.WriterTo) error {
+	p.slots <- struct{}{}
+	defer func() { <-p.slots }()
+
+	conn, reused, err := p.get()
+	if err != nil {
+		return err
+	}
+	err = sendOn(conn.client, from, to, msg)
+	if err == nil {
+		p.put(conn)
+		return nil
+	}
+	if p.release(conn, err) || !reused {
+		return err
+	}
+
+	if conn, err = p.dial(); err != nil {
+		return err
+	}
+	err = sendOn(conn.client, from, to, msg)
+	if err == nil {
+		p.put(conn)
+		return nil
+	}
+	p.release(conn, err)
+	return err
+}
+
+// release returns conn to the pool after a failed send if the server
+// answered with an SMTP reply and accepts RSET, and closes it otherwise.
+// It reports whether the server replied, i.e. whether the failure was
+// about the message rather than the connection.
+func (p *connPool) release(conn *pooledConn, err error) bool {
+	var protoErr *textproto.Error
+	if !errors.As(err, &protoErr) {
+		conn.client.Close()
+		return false
+	}
+	if conn.client.Reset() != nil {
+		conn.client.Close()
+		return true
+	}
+	p.put(conn)
+	return true
+}
+
+// get returns the most recently used idle connection that still answers
+// NOOP, or dials a new one. reused reports which it was.
+func (p *connPool) get() (conn *pooledConn, reused bool, err error) {
+	for {
+		p.mu.Lock()
+		if p.closed {
+			p.mu.Unlock()
+			return nil, false, errPoolClosed
+		}
+		expired := p.evictExpiredLocked(time.Now())
+		conn = nil
+		if n := len(p.idle); n > 0 {
+			conn = p.idle[n-1]
+			p.idle = p.idle[:n-1]
+		}
+		p.mu.Unlock()
+
+		closeAll(expired)
+		if conn == nil {
+			break
+		}
+		if conn.client.Noop() == nil {
+			return conn, true, nil
+		}
+		conn.client.Close()
+	}
+	conn, err = p.dial()
+	return conn, false, err
+}
+
+func (p *connPool) dial() (*pooledConn, error) {
+	client, err := p.dialer.dial()
+	if err != nil {
+		return nil, err
+	}
+	return &pooledConn{client: client}, nil
+}
+
+func (p *connPool) put(conn *pooledConn) {
+	conn.lastUsed = time.Now()
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		conn.quit()
+		return
+	}
+	p.idle = append(p.idle, conn)
+	p.mu.Unlock()
+}
+
+// reap closes expired idle connections until the pool is closed.
+func (p *connPool) reap() {
+	ticker := time.NewTicker(max(p.idleTimeout/2, 10*time.Millisecond))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.mu.Lock()
+			expired := p.evictExpiredLocked(time.Now())
+			p.mu.Unlock()
+			closeAll(expired)
+		case <-p.done:
+			return
+		}
+	}
+}
+
+// evictExpiredLocked removes the connections idle for longer than the
+// timeout and returns them for closing outside the lock.
+func (p *connPool) evictExpiredLocked(now time.Time) []*pooledConn {
+	keep := 0
+	for keep < len(p.idle) && now.Sub(p.idle[keep].lastUsed) > p.idleTimeout {
+		keep++
+	}
+	expired := p.idle[:keep:keep]
+	p.idle = p.idle[keep:]
+	return expired
+}
+
+// close closes every idle connection and stops the reaper; connections in
+// use are closed when they are returned.
+func (p *connPool) close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	idle := p.idle
+	p.idle = nil
+	p.closed = true
+	close(p.done)
+	p.mu.Unlock()
+
+	var errs []error
+	for _, conn := range idle {
+		if err := conn.quit(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func closeAll(conns []*pooledConn) {
+	for _, conn := range conns {
+		conn.quit()
+	}
+}
+
+// quit ends the session politely, and drops the connection if the server
+// does not answer.
+func (c *pooledConn) quit() error {
+	err := c.client.Quit()
+	if err != nil {
+		c.client.Close()
+	}
+	return err
+}
@@ -0,0 +1,277 @@
+package mailme
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"notification-service/internal/core/repository"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/gomail.v2"
+)
+
+// fakeSMTP is a minimal SMTP server on a local port: it accepts every
+// command, rejects recipients containing "reject" with 550, and can hang up
+// after each message to imitate a server dropping idle connections. It can
+// also refuse credentials, either at AUTH or by requiring it for MAIL.
+type fakeSMTP struct {
+	ln              net.Listener
+	hangUpAfterData bool
+	refuseAuth      bool
+	requireAuth     bool
+
+	dials    atomic.Int32
+	messages atomic.Int32
+	quits    atomic.Int32
+}
+
+func newFakeSMTP(tb testing.TB) *fakeSMTP {
+	tb.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		tb.Fatalf("listen: %v", err)
+	}
+	s := &fakeSMTP{ln: ln}
+	tb.Cleanup(func() { ln.Close() })
+	go s.serve()
+	return s
+}
+
+func (s *fakeSMTP) dialer() *smtpDialer {
+	host, port, _ := net.SplitHostPort(s.ln.Addr().String())
+	n, _ := strconv.Atoi(port)
+	return newSMTPDialer(host, n, "", "")
+}
+
+func (s *fakeSMTP) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		s.dials.Add(1)
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeSMTP) handle(conn net.Conn) {
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+	tp.PrintfLine("220 fake ESMTP")
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, _, _ := strings.Cut(strings.ToUpper(line), " ")
+		switch verb {
+		case "EHLO", "HELO":
+			tp.PrintfLine("250-fake")
+			if s.refuseAuth {
+				tp.PrintfLine("250-AUTH PLAIN")
+			}
+			tp.PrintfLine("250 8BITMIME")
+		case "AUTH":
+			tp.PrintfLine("535 5.7.8 authentication credentials invalid")
+		case "MAIL":
+			if s.requireAuth {
+				tp.PrintfLine("530 5.7.0 authentication required")
+				continue
+			}
+			tp.PrintfLine("250 OK")
+		case "RCPT":
+			if strings.Contains(line, "reject") {
+				tp.PrintfLine("550 5.1.1 no such user")
+				continue
+			}
+			tp.PrintfLine("250 OK")
+		case "DATA":
+			tp.PrintfLine("354 go ahead")
+			if _, err := tp.ReadDotBytes(); err != nil {
+				return
+			}
+			s.messages.Add(1)
+			tp.PrintfLine("250 queued")
+			if s.hangUpAfterData {
+				return
+			}
+		case "RSET", "NOOP":
+			tp.PrintfLine("250 OK")
+		case "QUIT":
+			s.quits.Add(1)
+			tp.PrintfLine("221 bye")
+			return
+		default:
+			tp.PrintfLine("502 unknown command")
+		}
+	}
+}
+
+func testMessage() *gomail.Message {
+	msg := gomail.NewMessage()
+	msg.SetHeader("From", "noreply@example.com")
+	msg.SetHeader("To", "admin@example.com")
+	msg.SetHeader("Subject", "Welcome")
+	msg.SetBody("text/plain", "Hello")
+	return msg
+}
+
+func TestMailerMarksRefusedCredentials(t *testing.T) {
+	tests := []struct {
+		name   string
+		server func(*fakeSMTP)
+	}{
+		{"auth refused", func(s *fakeSMTP) { s.refuseAuth = true }},
+		{"auth required", func(s *fakeSMTP) { s.requireAuth = true }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeSMTP(t)
+			tt.server(server)
+			host, port, _ := net.SplitHostPort(server.ln.Addr().String())
+			n, _ := strconv.Atoi(port)
+			mailer := NewMailer(host, n, "user", "wrong", "noreply@example.com", logrus.New())
+			defer mailer.Close()
+
+			_, err := mailer.Send(context.Background(), &repository.Email{
+				To:      []string{"admin@example.com"},
+				Subject: "Welcome",
+				HTML:    "<p>Hello</p>",
+			})
+			if !errors.Is(err, repository.ErrProviderAuth) {
+				t.Errorf("err = %v, want ErrProviderAuth", err)
+			}
+			if errors.Is(err, repository.ErrEmailRejected) {
+				t.Errorf("err = %v, must not be ErrEmailRejected", err)
+			}
+		})
+	}
+}
+
+func TestPoolReusesConnections(t *testing.T) {
+	server := newFakeSMTP(t)
+	pool := newConnPool(server.dialer(), 1, time.Minute)
+	defer pool.close()
+
+	for i := 0; i < 3; i++ {
+		if err := pool.send("noreply@example.com", []string{"admin@example.com"}, testMessage()); err != nil {
+			t.Fatalf("send %d: %v", i, err)
+		}
+	}
+	if got := server.dials.Load(); got != 1 {
+		t.Errorf("dials = %d, want 1", got)
+	}
+	if got := server.messages.Load(); got != 3 {
+		t.Errorf("messages = %d, want 3", got)
+	}
+}
+
+func TestPoolKeepsConnectionAfterRejection(t *testing.T) {
+	server := newFakeSMTP(t)
+	pool := newConnPool(server.dialer(), 1, time.Minute)
+	defer pool.close()
+
+	err := pool.send("noreply@example.com", []string{"reject@example.com"}, testMessage())
+	var protoErr *textproto.Error
+	if !errors.As(err, &protoErr) || protoErr.Code != 550 {
+		t.Fatalf("send to rejected recipient: err = %v, want a 550 reply", err)
+	}
+	if err := pool.send("noreply@example.com", []string{"admin@example.com"}, testMessage()); err != nil {
+		t.Fatalf("send after rejection: %v", err)
+	}
+	if got := server.dials.Load(); got != 1 {
+		t.Errorf("dials = %d, want 1: a rejection should not cost the connection", got)
+	}
+}
+
+func TestPoolReplacesDroppedConnection(t *testing.T) {
+	server := newFakeSMTP(t)
+	server.hangUpAfterData = true
+	pool := newConnPool(server.dialer(), 1, time.Minute)
+	defer pool.close()
+
+	for i := 0; i < 2; i++ {
+		if err := pool.send("noreply@example.com", []string{"admin@example.com"}, testMessage()); err != nil {
+			t.Fatalf("send %d: %v", i, err)
+		}
+	}
+	if got := server.dials.Load(); got != 2 {
+		t.Errorf("dials = %d, want 2: the dropped connection should be replaced", got)
+	}
+	if got := server.messages.Load(); got != 2 {
+		t.Errorf("messages = %d, want 2", got)
+	}
+}
+
+func TestPoolReapsIdleConnections(t *testing.T) {
+	server := newFakeSMTP(t)
+	pool := newConnPool(server.dialer(), 1, 20*time.Millisecond)
+	defer pool.close()
+
+	if err := pool.send("noreply@example.com", []string{"admin@example.com"}, testMessage()); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for server.quits.Load() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("idle connection was not closed by the reaper")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestPoolRejectsSendsAfterClose(t *testing.T) {
+	server := newFakeSMTP(t)
+	pool := newConnPool(server.dialer(), 1, time.Minute)
+	if err := pool.send("noreply@example.com", []string{"admin@example.com"}, testMessage()); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if err := pool.close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if err := pool.send("noreply@example.com", []string{"admin@example.com"}, testMessage()); !errors.Is(err, errPoolClosed) {
+		t.Errorf("send after close: err = %v, want errPoolClosed", err)
+	}
+	if got := server.quits.Load(); got != 1 {
+		t.Errorf("quits = %d, want 1", got)
+	}
+}
+
+func BenchmarkSendPooled(b *testing.B) {
+	server := newFakeSMTP(b)
+	pool := newConnPool(server.dialer(), 1, time.Minute)
+	defer pool.close()
+	msg := testMessage()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := pool.send("noreply@example.com", []string{"admin@example.com"}, msg); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSendDialPerSend(b *testing.B) {
+	server := newFakeSMTP(b)
+	dialer := server.dialer()
+	msg := testMessage()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		client, err := dialer.dial()
+		if err != nil {
+			b.Fatal(err)
+		}
+		if err := sendOn(client, "noreply@example.com", []string{"admin@example.com"}, msg); err != nil {
+			b.Fatal(err)
+		}
+		client.Quit()
+	}
+}
@@ -0,0 +1,208 @@
+package mailme
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"html/template"
+	"sort"
+	"sync"
+)
+
+// ErrRender marks template rendering failures; retrying them cannot succeed.
+var ErrRender = errors.New("template rendering failed")
+
+// maxCachedTemplates bounds the parsed-template cache; edited templates leave
+// their old versions behind, so the cache is simply reset when it fills up.
+const maxCachedTemplates = 256
+
+// funcs is shared by every parsed template.
+var funcs = Funcs()
+
+// Renderer renders email templates, caching parsed templates by content.
+type Renderer struct {
+	mu     sync.RWMutex
+	parsed map[[sha256.Size]byte]*template.Template
+}
+
+// NewRenderer creates a new instance of the Renderer.
+func NewRenderer() *Renderer {
+	return &Renderer{}
+}
+
+// Content is the template source of an email.
+type Content struct {
+	Subject string
+	HTML    string
+	Text    string
+	// Layout optionally wraps the HTML body, which it includes with
+	// {{template "content" .}}.
+	Layout string
+	// Partials are named templates shared by the HTML body and layout.
+	Partials map[string]string
+	// InlineCSS moves <style> rules into style attributes after rendering.
+	InlineCSS bool
+}
+
+// Rendered holds the rendered parts of an email.
+type Rendered struct {
+	Subject string
+	HTML    string
+	Text    string
+	// TextErr is set when the plain text template failed to render. Text is
+	// then generated from the HTML instead, so this is not a fatal error.
+	TextErr error
+	// TextGenerated reports that Text was derived from HTML rather than
+	// rendered from a text template.
+	TextGenerated bool
+}
+
+// Render renders the subject, HTML and plain text templates against data,
+// for sending and for previews.
+func (r *Renderer) Render(content *Content, data map[string]interface{}) (*Rendered, error) {
+	// 1. Render Subject
+	subject, err := r.render("subject", content.Subject, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render subject template: %w", err)
+	}
+
+	// The rendered subject is available to the bodies as {{.subject}}.
+	data = withSubject(data, subject)
+
+	// 2. Render HTML Body, together with its layout and partials
+	htmlTmpl, err := r.parseHTML(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render html template: %w: %v", ErrRender, err)
+	}
+	htmlBody, err := execute(htmlTmpl, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render html template: %w", err)
+	}
+	if content.InlineCSS {
+		if htmlBody, err = InlineCSS(htmlBody); err != nil {
+			return nil, fmt.Errorf("failed to inline css: %w: %v", ErrRender, err)
+		}
+	}
+
+	rendered := &Rendered{Subject: subject, HTML: htmlBody}
+
+	// 3. Render Plain Text Body, falling back to a version derived from the HTML
+	if content.Text != "" {
+		rendered.Text, rendered.TextErr = r.render("text", content.Text, data)
+	}
+	if content.Text == "" || rendered.TextErr != nil {
+		rendered.Text = PlainText(htmlBody)
+		rendered.TextGenerated = true
+	}
+	return rendered, nil
+}
+
+// render is a helper function to parse and execute a template string.
+func (r *Renderer) render(templateName, templateStr string, data map[string]interface{}) (string, error) {
+	tmpl, err := r.parse(templateName, templateStr)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrRender, err)
+	}
+	return execute(tmpl, data)
+}
+
+func execute(tmpl *template.Template, data map[string]interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrRender, err)
+	}
+	return buf.String(), nil
+}
+
+// parse returns the parsed template for the given source, parsing it only the
+// first time it is seen. Templates are keyed by content, so an edited
+// template is parsed afresh without any explicit invalidation.
+func (r *Renderer) parse(templateName, templateStr string) (*template.Template, error) {
+	key := sha256.Sum256([]byte(templateName + "\x00" + templateStr))
+	return r.cached(key, func() (*template.Template, error) {
+		return template.New(templateName).Funcs(funcs).Parse(templateStr)
+	})
+}
+
+// parseHTML parses the HTML body as one template set with its partials and
+// layout. The body is parsed last so its {{define}}s override the layout's
+// {{block}}s. The returned template is the layout if there is one, and the
+// body otherwise.
+func (r *Renderer) parseHTML(content *Content) (*template.Template, error) {
+	if content.Layout == "" && len(content.Partials) == 0 {
+		return r.parse("html", content.HTML)
+	}
+
+	names := make([]string, 0, len(content.Partials))
+	for name := range content.Partials {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	hash := sha256.New()
+	for _, name := range names {
+		fmt.Fprintf(hash, "partial\x00%s\x00%s\x00", name, content.Partials[name])
+	}
+	fmt.Fprintf(hash, "layout\x00%s\x00html\x00%s", content.Layout, content.HTML)
+	var key [sha256.Size]byte
+	copy(key[:], hash.Sum(nil))
+
+	return r.cached(key, func() (*template.Template, error) {
+		set := template.New("layout").Funcs(funcs)
+		for _, name := range names {
+			if _, err := set.New(name).Parse(content.Partials[name]); err != nil {
+				return nil, fmt.Errorf("partial %q: %w", name, err)
+			}
+		}
+		if content.Layout != "" {
+			if _, err := set.Parse(content.Layout); err != nil {
+				return nil, fmt.Errorf("layout: %w", err)
+			}
+		}
+		body, err := set.New("content").Parse(content.HTML)
+		if err != nil {
+			return nil, err
+		}
+		if content.Layout == "" {
+			return body, nil
+		}
+		return set, nil
+	})
+}
+
+func (r *Renderer) cached(key [sha256.Size]byte, parse func() (*template.Template, error)) (*template.Template, error) {
+	r.mu.RLock()
+	tmpl, ok := r.parsed[key]
+	r.mu.RUnlock()
+	if ok {
+		return tmpl, nil
+	}
+
+	tmpl, err := parse()
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	if r.parsed == nil || len(r.parsed) >= maxCachedTemplates {
+		r.parsed = make(map[[sha256.Size]byte]*template.Template)
+	}
+	r.parsed[key] = tmpl
+	r.mu.Unlock()
+	return tmpl, nil
+}
+
+// withSubject returns a copy of data with the rendered subject added, unless
+// the caller supplied its own.
+func withSubject(data map[string]interface{}, subject string) map[string]interface{} {
+	if _, ok := data["subject"]; ok {
+		return data
+	}
+	out := make(map[string]interface{}, len(data)+1)
+	for key, value := range data {
+		out[key] = value
+	}
+	out["subject"] = subject
+	return out
+}
@@ -0,0 +1,158 @@
+package mailme
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"notification-service/internal/core/repository"
+)
+
+// dialTimeout bounds connecting to the SMTP server, as gomail does.
+const dialTimeout = 10 * time.Second
+
+// smtpDialer opens authenticated SMTP connections the way gomail.Dialer
+// does: implicit TLS on port 465, otherwise STARTTLS when the server offers
+// it, then AUTH with CRAM-MD5, LOGIN or PLAIN. Unlike gomail it hands out
+// the *smtp.Client, so the pool can check idle connections with NOOP and
+// reset them after a rejected message.
+type smtpDialer struct {
+	host     string
+	port     int
+	username string
+	password string
+	ssl      bool
+}
+
+func newSMTPDialer(host string, port int, username, password string) *smtpDialer {
+	return &smtpDialer{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		ssl:      port == 465,
+	}
+}
+
+func (d *smtpDialer) dial() (*smtp.Client, error) {
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(d.host, strconv.Itoa(d.port)), dialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	if d.ssl {
+		conn = tls.Client(conn, &tls.Config{ServerName: d.host})
+	}
+
+	c, err := smtp.NewClient(conn, d.host)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if !d.ssl {
+		if ok, _ := c.Extension("STARTTLS"); ok {
+			if err := c.StartTLS(&tls.Config{ServerName: d.host}); err != nil {
+				c.Close()
+				return nil, err
+			}
+		}
+	}
+	if d.username != "" {
+		if ok, mechanisms := c.Extension("AUTH"); ok {
+			if err := c.Auth(d.auth(mechanisms)); err != nil {
+				c.Close()
+				return nil, fmt.Errorf("%w: %w", repository.ErrProviderAuth, err)
+			}
+		}
+	}
+	return c, nil
+}
+
+// auth picks the mechanism gomail would for the advertised AUTH list.
+func (d *smtpDialer) auth(mechanisms string) smtp.Auth {
+	switch {
+	case strings.Contains(mechanisms, "CRAM-MD5"):
+		return smtp.CRAMMD5Auth(d.username, d.password)
+	case strings.Contains(mechanisms, "LOGIN") && !strings.Contains(mechanisms, "PLAIN"):
+		return &loginAuth{username: d.username, password: d.password, host: d.host}
+	default:
+		return smtp.PlainAuth("", d.username, d.password, d.host)
+	}
+}
+
+// isAuthReply reports whether err is a server reply refusing the session's
+// authentication: 530 authentication required, 534 mechanism too weak,
+// 535 credentials invalid or 538 encryption required.
+func isAuthReply(err error) bool {
+	var protoErr *textproto.Error
+	if !errors.As(err, &protoErr) {
+		return false
+	}
+	switch protoErr.Code {
+	case 530, 534, 535, 538:
+		return true
+	}
+	return false
+}
+
+// sendOn runs one mail transaction on c. The connection stays usable for
+// the next one when this returns nil.
+func sendOn(c *smtp.Client, from string, to []string, msg io.WriterTo) error {
+	if err := c.Mail(from); err != nil {
+		return err
+	}
+	for _, addr := range to {
+		if err := c.Rcpt(addr); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := msg.WriteTo(w); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// loginAuth implements the LOGIN mechanism, which net/smtp lacks but some
+// servers, such as Office 365, require.
+type loginAuth struct {
+	username string
+	password string
+	host     string
+}
+
+func (a *loginAuth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	// Like gomail, allow plaintext only when the server advertises LOGIN.
+	if !server.TLS && !slices.Contains(server.Auth, "LOGIN") {
+		return "", nil, errors.New("refusing LOGIN auth over an unencrypted connection")
+	}
+	if server.Name != a.host {
+		return "", nil, errors.New("wrong host name for LOGIN auth")
+	}
+	return "LOGIN", nil, nil
+}
+
+func (a *loginAuth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if !more {
+		return nil, nil
+	}
+	switch {
+	case bytes.Equal(fromServer, []byte("Username:")):
+		return []byte(a.username), nil
+	case bytes.Equal(fromServer, []byte("Password:")):
+		return []byte(a.password), nil
+	}
+	return nil, fmt.Errorf("unexpected LOGIN challenge %q", fromServer)
+}
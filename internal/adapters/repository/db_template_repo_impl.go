@@ -0,0 +1,284 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"notification-service/internal/adapters/database/db"
+	"notification-service/internal/core/repository"
+
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+)
+
+// DBTemplateRepo serves the active version of templates stored in Postgres
+// and implements repository.TemplateStore for managing them.
+type DBTemplateRepo struct {
+	conn   *sql.DB
+	db     *db.Queries
+	logger *logrus.Logger
+}
+
+func NewDBTemplateRepo(conn *sql.DB, logger *logrus.Logger) *DBTemplateRepo {
+	return &DBTemplateRepo{
+		conn:   conn,
+		db:     db.New(conn),
+		logger: logger,
+	}
+}
+
+// GetTemplate returns the active version of the closest locale variant.
+func (r *DBTemplateRepo) GetTemplate(ctx context.Context, name, locale string) (*repository.Template, error) {
+	for _, candidate := range repository.LocaleFallbacks(locale) {
+		row, err := r.db.GetActiveTemplateVersion(ctx, db.GetActiveTemplateVersionParams{Name: name, Locale: candidate})
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not load template %s: %w", name, err)
+		}
+
+		var meta repository.TemplateMeta
+		if err := json.Unmarshal(row.Meta, &meta); err != nil {
+			r.logger.WithError(err).WithField("template_name", name).Error("Failed to parse template meta")
+			return nil, fmt.Errorf("could not parse meta for template %s version %d: %w", name, row.Version, err)
+		}
+
+		return &repository.Template{
+			Name:        row.Name,
+			Version:     int(row.Version),
+			Locale:      row.Locale,
+			Subject:     row.Subject,
+			BodyHTML:    row.BodyHtml,
+			BodyText:    row.BodyText,
+			BodySMS:     row.BodySms,
+			BodyWebhook: row.BodyWebhook,
+			BodyChat:    row.BodyChat,
+			BodyInApp:   row.BodyInapp,
+			Meta:        meta,
+		}, nil
+	}
+	return nil, fmt.Errorf("template %s has no active version: %w", name, repository.ErrTemplateNotFound)
+}
+
+func (r *DBTemplateRepo) CreateTemplate(ctx context.Context, tmpl *repository.Template, activate bool) (*repository.TemplateSummary, *repository.Template, error) {
+	var created *repository.Template
+	err := r.inTx(ctx, func(q *db.Queries) error {
+		row, err := q.CreateTemplate(ctx, db.CreateTemplateParams{Name: tmpl.Name, Locale: tmpl.Locale})
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" { // unique_violation
+			return fmt.Errorf("%s: %w", templateLabel(tmpl.Name, tmpl.Locale), repository.ErrTemplateExists)
+		}
+		if err != nil {
+			return err
+		}
+		created, err = r.insertVersion(ctx, q, row.ID, tmpl, activate)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	summary, err := r.GetTemplateSummary(ctx, tmpl.Name, tmpl.Locale)
+	return summary, created, err
+}
+
+func (r *DBTemplateRepo) AddVersion(ctx context.Context, tmpl *repository.Template, activate bool) (*repository.TemplateSummary, *repository.Template, error) {
+	var created *repository.Template
+	err := r.inTx(ctx, func(q *db.Queries) error {
+		// Lock the template row so concurrent updates get distinct version numbers.
+		row, err := q.LockTemplateByName(ctx, db.LockTemplateByNameParams{Name: tmpl.Name, Locale: tmpl.Locale})
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", templateLabel(tmpl.Name, tmpl.Locale), repository.ErrTemplateNotFound)
+		}
+		if err != nil {
+			return err
+		}
+		created, err = r.insertVersion(ctx, q, row.ID, tmpl, activate)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	summary, err := r.GetTemplateSummary(ctx, tmpl.Name, tmpl.Locale)
+	return summary, created, err
+}
+
+func (r *DBTemplateRepo) ListTemplates(ctx context.Context) ([]repository.TemplateSummary, error) {
+	rows, err := r.db.ListTemplateSummaries(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not list templates: %w", err)
+	}
+
+	summaries := make([]repository.TemplateSummary, 0, len(rows))
+	for _, row := range rows {
+		summaries = append(summaries, repository.TemplateSummary{
+			Name:          row.Name,
+			Locale:        row.Locale,
+			ActiveVersion: int(row.ActiveVersion),
+			LatestVersion: int(row.LatestVersion),
+			CreatedAt:     row.CreatedAt,
+			UpdatedAt:     row.UpdatedAt,
+		})
+	}
+	return summaries, nil
+}
+
+func (r *DBTemplateRepo) GetTemplateSummary(ctx context.Context, name, locale string) (*repository.TemplateSummary, error) {
+	row, err := r.db.GetTemplateSummary(ctx, db.GetTemplateSummaryParams{Name: name, Locale: locale})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%s: %w", templateLabel(name, locale), repository.ErrTemplateNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not load template %s: %w", templateLabel(name, locale), err)
+	}
+
+	return &repository.TemplateSummary{
+		Name:          row.Name,
+		Locale:        row.Locale,
+		ActiveVersion: int(row.ActiveVersion),
+		LatestVersion: int(row.LatestVersion),
+		CreatedAt:     row.CreatedAt,
+		UpdatedAt:     row.UpdatedAt,
+	}, nil
+}
+
+func (r *DBTemplateRepo) GetVersion(ctx context.Context, name, locale string, version int) (*repository.Template, error) {
+	if version == 0 {
+		summary, err := r.GetTemplateSummary(ctx, name, locale)
+		if err != nil {
+			return nil, err
+		}
+		if summary.ActiveVersion == 0 {
+			return nil, fmt.Errorf("%s has no active version: %w", templateLabel(name, locale), repository.ErrTemplateVersionNotFound)
+		}
+		version = summary.ActiveVersion
+	}
+
+	row, err := r.db.GetTemplateVersion(ctx, db.GetTemplateVersionParams{Name: name, Locale: locale, Version: int32(version)})
+	if errors.Is(err, sql.ErrNoRows) {
+		if _, summaryErr := r.GetTemplateSummary(ctx, name, locale); summaryErr != nil {
+			return nil, summaryErr
+		}
+		return nil, fmt.Errorf("%s version %d: %w", templateLabel(name, locale), version, repository.ErrTemplateVersionNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not load template %s version %d: %w", templateLabel(name, locale), version, err)
+	}
+	return toTemplate(name, locale, row)
+}
+
+func (r *DBTemplateRepo) ActivateVersion(ctx context.Context, name, locale string, version int) (*repository.TemplateSummary, error) {
+	err := r.inTx(ctx, func(q *db.Queries) error {
+		tmpl, err := q.LockTemplateByName(ctx, db.LockTemplateByNameParams{Name: name, Locale: locale})
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", templateLabel(name, locale), repository.ErrTemplateNotFound)
+		}
+		if err != nil {
+			return err
+		}
+		row, err := q.GetTemplateVersion(ctx, db.GetTemplateVersionParams{Name: name, Locale: locale, Version: int32(version)})
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s version %d: %w", templateLabel(name, locale), version, repository.ErrTemplateVersionNotFound)
+		}
+		if err != nil {
+			return err
+		}
+		return q.SetActiveTemplateVersion(ctx, db.SetActiveTemplateVersionParams{
+			ID:              tmpl.ID,
+			ActiveVersionID: sql.NullInt32{Int32: row.ID, Valid: true},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.GetTemplateSummary(ctx, name, locale)
+}
+
+func (r *DBTemplateRepo) DeleteTemplate(ctx context.Context, name, locale string) error {
+	deleted, err := r.db.DeleteTemplate(ctx, db.DeleteTemplateParams{Name: name, Locale: locale})
+	if err != nil {
+		return fmt.Errorf("could not delete template %s: %w", templateLabel(name, locale), err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("%s: %w", templateLabel(name, locale), repository.ErrTemplateNotFound)
+	}
+	return nil
+}
+
+func (r *DBTemplateRepo) insertVersion(ctx context.Context, q *db.Queries, templateID int32, tmpl *repository.Template, activate bool) (*repository.Template, error) {
+	meta, err := json.Marshal(tmpl.Meta)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode meta for template %s: %w", tmpl.Name, err)
+	}
+
+	row, err := q.CreateTemplateVersion(ctx, db.CreateTemplateVersionParams{
+		TemplateID:  templateID,
+		Subject:     tmpl.Subject,
+		BodyHtml:    tmpl.BodyHTML,
+		BodyText:    tmpl.BodyText,
+		BodySms:     tmpl.BodySMS,
+		BodyWebhook: tmpl.BodyWebhook,
+		BodyChat:    tmpl.BodyChat,
+		BodyInapp:   tmpl.BodyInApp,
+		Meta:        meta,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if activate {
+		err = q.SetActiveTemplateVersion(ctx, db.SetActiveTemplateVersionParams{
+			ID:              templateID,
+			ActiveVersionID: sql.NullInt32{Int32: row.ID, Valid: true},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return toTemplate(tmpl.Name, tmpl.Locale, row)
+}
+
+func (r *DBTemplateRepo) inTx(ctx context.Context, fn func(q *db.Queries) error) error {
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(r.db.WithTx(tx)); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func toTemplate(name, locale string, row db.TemplateVersion) (*repository.Template, error) {
+	var meta repository.TemplateMeta
+	if err := json.Unmarshal(row.Meta, &meta); err != nil {
+		return nil, fmt.Errorf("could not parse meta for template %s version %d: %w", name, row.Version, err)
+	}
+	return &repository.Template{
+		Name:        name,
+		Version:     int(row.Version),
+		Locale:      locale,
+		Subject:     row.Subject,
+		BodyHTML:    row.BodyHtml,
+		BodyText:    row.BodyText,
+		BodySMS:     row.BodySms,
+		BodyWebhook: row.BodyWebhook,
+		BodyChat:    row.BodyChat,
+		BodyInApp:   row.BodyInapp,
+		Meta:        meta,
+	}, nil
+}
+
+// templateLabel names a template variant in error messages, e.g. "welcome (de-DE)".
+func templateLabel(name, locale string) string {
+	if locale == "" {
+		return name
+	}
+	return fmt.Sprintf("%s (%s)", name, locale)
+}
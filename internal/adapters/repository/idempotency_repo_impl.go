@@ -0,0 +1,76 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"notification-service/internal/adapters/database/db"
+	"notification-service/internal/core/repository"
+	"time"
+)
+
+type IdempotencyRepo struct {
+	db *db.Queries
+}
+
+func NewIdempotencyRepo(conn *sql.DB) *IdempotencyRepo {
+	return &IdempotencyRepo{
+		db: db.New(conn),
+	}
+}
+
+func (r *IdempotencyRepo) Claim(ctx context.Context, key, notificationID string, lease time.Duration) (repository.IdempotencyClaim, error) {
+	now := time.Now()
+	_, err := r.db.ClaimIdempotencyKey(ctx, db.ClaimIdempotencyKeyParams{
+		Key:            key,
+		NotificationID: notificationID,
+		CreatedAt:      now,
+		ExpiresAt:      now.Add(lease),
+	})
+	if err == nil {
+		return repository.ClaimAcquired, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return 0, err
+	}
+
+	status, err := r.db.GetIdempotencyKeyStatus(ctx, key)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Released since the claim; the caller's retry will take it.
+		return repository.ClaimPending, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if status == "done" {
+		return repository.ClaimDone, nil
+	}
+	return repository.ClaimPending, nil
+}
+
+func (r *IdempotencyRepo) Complete(ctx context.Context, key, notificationID string, ttl time.Duration) error {
+	updated, err := r.db.CompleteIdempotencyKey(ctx, db.CompleteIdempotencyKeyParams{
+		Key:            key,
+		NotificationID: notificationID,
+		ExpiresAt:      time.Now().Add(ttl),
+	})
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return fmt.Errorf("idempotency key %q is no longer held by %s", key, notificationID)
+	}
+	return nil
+}
+
+func (r *IdempotencyRepo) Release(ctx context.Context, key, notificationID string) error {
+	return r.db.ReleaseIdempotencyKey(ctx, db.ReleaseIdempotencyKeyParams{
+		Key:            key,
+		NotificationID: notificationID,
+	})
+}
+
+func (r *IdempotencyRepo) DeleteExpired(ctx context.Context) (int64, error) {
+	return r.db.DeleteExpiredIdempotencyKeys(ctx, time.Now())
+}
@@ -0,0 +1,65 @@
+package database
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+)
+
+// inboxChannel is the NOTIFY channel the inbox_notifications trigger uses.
+const inboxChannel = "inbox_notifications"
+
+// InboxFeed receives inbox notifications as they are created, on whichever
+// service instance created them.
+type InboxFeed interface {
+	// Publish is called with the ID of each new inbox notification.
+	Publish(ctx context.Context, id int64)
+	// Resync is called after the connection was lost, when notifications
+	// may have been missed.
+	Resync()
+}
+
+// ListenInbox LISTENs for new inbox notifications on a dedicated connection
+// and hands them to feed until ctx is done. The connection is re-established
+// with backoff if it drops.
+func ListenInbox(ctx context.Context, dataSource string, feed InboxFeed, logger *logrus.Logger) error {
+	listener := pq.NewListener(dataSource, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		switch event {
+		case pq.ListenerEventDisconnected:
+			logger.WithError(err).Warn("Inbox listener lost its database connection")
+		case pq.ListenerEventReconnected:
+			logger.Info("Inbox listener reconnected")
+		case pq.ListenerEventConnectionAttemptFailed:
+			logger.WithError(err).Warn("Inbox listener failed to reconnect")
+		}
+	})
+	defer listener.Close()
+	if err := listener.Listen(inboxChannel); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case n := <-listener.Notify:
+			if n == nil {
+				// Sent after a reconnect; anything in between is lost.
+				feed.Resync()
+				continue
+			}
+			id, err := strconv.ParseInt(n.Extra, 10, 64)
+			if err != nil {
+				logger.WithField("payload", n.Extra).Warn("Ignoring malformed inbox notification")
+				continue
+			}
+			feed.Publish(ctx, id)
+		case <-time.After(90 * time.Second):
+			// Notices a dead connection that has not been reported yet.
+			go listener.Ping()
+		}
+	}
+}
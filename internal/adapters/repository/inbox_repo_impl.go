@@ -0,0 +1,57 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"notification-service/internal/adapters/database/db"
+	"time"
+)
+
+type InboxRepo struct {
+	db *db.Queries
+}
+
+func NewInboxRepo(conn *sql.DB) *InboxRepo {
+	return &InboxRepo{
+		db: db.New(conn),
+	}
+}
+
+func (r *InboxRepo) Create(ctx context.Context, params db.CreateInboxNotificationParams) (db.InboxNotification, bool, error) {
+	entry, err := r.db.CreateInboxNotification(ctx, params)
+	if errors.Is(err, sql.ErrNoRows) {
+		return db.InboxNotification{}, false, nil
+	}
+	if err != nil {
+		return db.InboxNotification{}, false, err
+	}
+	return entry, true, nil
+}
+
+func (r *InboxRepo) Get(ctx context.Context, id int64) (db.InboxNotification, error) {
+	return r.db.GetInboxNotification(ctx, id)
+}
+
+func (r *InboxRepo) List(ctx context.Context, params db.ListInboxNotificationsParams) ([]db.InboxNotification, error) {
+	return r.db.ListInboxNotifications(ctx, params)
+}
+
+func (r *InboxRepo) CountUnread(ctx context.Context, userID string) (int64, error) {
+	return r.db.CountUnreadInboxNotifications(ctx, userID)
+}
+
+func (r *InboxRepo) MarkRead(ctx context.Context, userID string, ids []int64, at time.Time) (int64, error) {
+	return r.db.MarkInboxNotificationsRead(ctx, db.MarkInboxNotificationsReadParams{
+		ReadAt: sql.NullTime{Time: at, Valid: true},
+		UserID: userID,
+		Ids:    ids,
+	})
+}
+
+func (r *InboxRepo) MarkAllRead(ctx context.Context, userID string, at time.Time) (int64, error) {
+	return r.db.MarkAllInboxNotificationsRead(ctx, db.MarkAllInboxNotificationsReadParams{
+		UserID: userID,
+		ReadAt: sql.NullTime{Time: at, Valid: true},
+	})
+}
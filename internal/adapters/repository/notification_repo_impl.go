@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"notification-service/internal/adapters/database/db"
+	"time"
 )
 
 type NotificationLogRepo struct {
@@ -19,3 +20,26 @@ func NewNotificationLogRepo(conn *sql.DB) *NotificationLogRepo {
 func (r *NotificationLogRepo) CreateLog(ctx context.Context, params db.CreateNotificationLogParams) error {
 	return r.db.CreateNotificationLog(ctx, params)
 }
+
+func (r *NotificationLogRepo) ListFailedLogs(ctx context.Context, params db.ListFailedNotificationLogsParams) ([]db.NotificationLog, error) {
+	return r.db.ListFailedNotificationLogs(ctx, params)
+}
+
+func (r *NotificationLogRepo) MarkReplayed(ctx context.Context, id int32, at time.Time) error {
+	return r.db.MarkNotificationLogReplayed(ctx, db.MarkNotificationLogReplayedParams{
+		ID:         id,
+		ReplayedAt: sql.NullTime{Time: at, Valid: true},
+	})
+}
+
+func (r *NotificationLogRepo) GetLog(ctx context.Context, id int32) (db.NotificationLog, error) {
+	return r.db.GetNotificationLog(ctx, id)
+}
+
+func (r *NotificationLogRepo) GetLatestLogByNotificationID(ctx context.Context, notificationID string) (db.NotificationLog, error) {
+	return r.db.GetLatestNotificationLogByNotificationID(ctx, sql.NullString{String: notificationID, Valid: true})
+}
+
+func (r *NotificationLogRepo) ListLogs(ctx context.Context, params db.ListNotificationLogsParams) ([]db.NotificationLog, error) {
+	return r.db.ListNotificationLogs(ctx, params)
+}
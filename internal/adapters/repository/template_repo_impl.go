@@ -2,65 +2,342 @@ package database
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
+	"net/mail"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
+	"time"
 
 	"notification-service/internal/core/repository"
 
 	"github.com/sirupsen/logrus"
 )
 
+// FileTemplateRepo loads templates from a directory tree, one directory per
+// template. Locale variants live in subdirectories named after the locale,
+// e.g. welcome/de-DE/subject.txt; files missing from a variant fall back
+// along the locale chain to the template directory itself. Loaded templates
+// are cached until Watch sees their files change.
+//
+// The layouts/ and partials/ directories of the root are shared by every
+// template: a template picks a layout with "layout" in its meta.json, and
+// each partials/<name>.html is available to it as {{template "<name>" .}}.
 type FileTemplateRepo struct {
 	basePath string
 	logger   *logrus.Logger
+
+	mu    sync.RWMutex
+	cache map[templateKey]*repository.Template
+	// generations count the evictions of each template, and epoch those of
+	// the whole cache, so a load racing an eviction is not cached.
+	generations map[string]uint64
+	epoch       uint64
+}
+
+// Shared template directories in the template root.
+const (
+	layoutsDir  = "layouts"
+	partialsDir = "partials"
+)
+
+type templateKey struct {
+	name   string
+	locale string
 }
 
 func NewFileTemplateRepo(basePath string, logger *logrus.Logger) *FileTemplateRepo {
 	return &FileTemplateRepo{
-		basePath: basePath,
-		logger:   logger,
+		basePath:    basePath,
+		logger:      logger,
+		cache:       make(map[templateKey]*repository.Template),
+		generations: make(map[string]uint64),
 	}
 }
 
-func (r *FileTemplateRepo) GetTemplate(ctx context.Context, name string) (*repository.Template, error) {
-	log := r.logger.WithField("template_name", name)
-	templateDir := filepath.Join(r.basePath, name)
+// GetTemplate returns the named template in the closest available locale,
+// reading it from disk on a cache miss. The returned template is shared and
+// must not be modified.
+func (r *FileTemplateRepo) GetTemplate(ctx context.Context, name, locale string) (*repository.Template, error) {
+	if name == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") || name == layoutsDir || name == partialsDir {
+		return nil, fmt.Errorf("%w: invalid template name %q", repository.ErrTemplateNotFound, name)
+	}
+	locale, ok := repository.NormalizeLocale(locale)
+	if !ok {
+		locale = ""
+	}
+	key := templateKey{name: name, locale: locale}
+
+	r.mu.RLock()
+	cached, ok := r.cache[key]
+	generation, epoch := r.generations[name], r.epoch
+	r.mu.RUnlock()
+	if ok {
+		return cached, nil
+	}
 
-	subject, err := r.readFile(filepath.Join(templateDir, "subject.txt"))
+	template, err := r.load(name, locale)
 	if err != nil {
-		log.WithError(err).Error("Failed to read subject.txt")
 		return nil, err
 	}
 
-	bodyHTML, err := r.readFile(filepath.Join(templateDir, "body.html"))
+	// If Watch evicted the template while it was read, the files may have
+	// changed under the read; serve it, but leave it to the next call.
+	r.mu.Lock()
+	if r.generations[name] == generation && r.epoch == epoch {
+		r.cache[key] = template
+	}
+	r.mu.Unlock()
+	return template, nil
+}
+
+// Watch polls the template directory every interval and evicts cached
+// templates whose files were added, changed or removed, so edits go live
+// without a restart. It blocks until ctx is cancelled. An interval of zero
+// or less disables reloading, and Watch returns at once.
+func (r *FileTemplateRepo) Watch(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		r.logger.Info("Template reloading is disabled")
+		return
+	}
+	previous := r.fingerprints()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		current := r.fingerprints()
+		if previous[layoutsDir] != current[layoutsDir] || previous[partialsDir] != current[partialsDir] {
+			// Every template may render these, so start over.
+			r.evictAll()
+			previous = current
+			continue
+		}
+		for name, fp := range current {
+			if previous[name] != fp {
+				r.evict(name)
+			}
+		}
+		for name := range previous {
+			if _, ok := current[name]; !ok {
+				r.evict(name)
+			}
+		}
+		previous = current
+	}
+}
+
+func (r *FileTemplateRepo) evict(name string) {
+	r.mu.Lock()
+	r.generations[name]++
+	cached := false
+	for key := range r.cache {
+		if key.name == name {
+			delete(r.cache, key)
+			cached = true
+		}
+	}
+	r.mu.Unlock()
+	if cached {
+		r.logger.WithField("template_name", name).Info("Template changed on disk, reloading")
+	}
+}
+
+func (r *FileTemplateRepo) evictAll() {
+	r.mu.Lock()
+	r.cache = make(map[templateKey]*repository.Template)
+	r.epoch++
+	r.mu.Unlock()
+	r.logger.Info("Shared layouts or partials changed on disk, reloading all templates")
+}
+
+// fingerprints summarises the files under each top-level entry of the
+// template directory by path, size and modification time.
+func (r *FileTemplateRepo) fingerprints() map[string]string {
+	prints := make(map[string]string)
+	err := filepath.WalkDir(r.basePath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(r.basePath, path)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		name := strings.SplitN(filepath.ToSlash(rel), "/", 2)[0]
+		prints[name] += fmt.Sprintf("%s:%d:%d;", rel, info.Size(), info.ModTime().UnixNano())
+		return nil
+	})
 	if err != nil {
+		r.logger.WithError(err).Warn("Failed to scan template directory for changes")
+	}
+	return prints
+}
+
+func (r *FileTemplateRepo) load(name, locale string) (*repository.Template, error) {
+	log := r.logger.WithFields(logrus.Fields{"template_name": name, "locale": locale})
+
+	// The most specific locale that has its own subject.txt is the variant
+	// used; its remaining files fall back along the rest of the chain.
+	chain := repository.LocaleFallbacks(locale)
+	for len(chain) > 1 {
+		if _, err := os.Stat(r.templateFile(name, chain[0], "subject.txt")); err == nil {
+			break
+		}
+		chain = chain[1:]
+	}
+
+	subject, err := r.readLocalized(name, chain, "subject.txt")
+	if errors.Is(err, fs.ErrNotExist) {
+		log.WithError(err).Error("Failed to read subject.txt")
+		return nil, fmt.Errorf("%w: %v", repository.ErrTemplateNotFound, err)
+	}
+	if err != nil {
+		log.WithError(err).Error("Failed to read subject.txt")
+		return nil, err
+	}
+
+	// Bodies for other channels are optional; a template with one of them
+	// need not have body.html.
+	bodySMS, err := r.readLocalized(name, chain, "body.sms.txt")
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.WithError(err).Error("Failed to read body.sms.txt")
+		return nil, err
+	}
+	bodyWebhook, err := r.readLocalized(name, chain, "body.webhook.json")
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.WithError(err).Error("Failed to read body.webhook.json")
+		return nil, err
+	}
+	bodyChat, err := r.readLocalized(name, chain, "body.chat.json")
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.WithError(err).Error("Failed to read body.chat.json")
+		return nil, err
+	}
+
+	bodyInApp, err := r.readLocalized(name, chain, "body.inapp.txt")
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.WithError(err).Error("Failed to read body.inapp.txt")
+		return nil, err
+	}
+
+	bodyHTML, err := r.readLocalized(name, chain, "body.html")
+	if err != nil && (bodySMS == "" && bodyWebhook == "" && bodyChat == "" && bodyInApp == "" || !errors.Is(err, fs.ErrNotExist)) {
 		log.WithError(err).Error("Failed to read body.html")
 		return nil, err
 	}
 
-	bodyText, err := r.readFile(filepath.Join(templateDir, "body.txt"))
+	bodyText, err := r.readLocalized(name, chain, "body.txt")
 	if err != nil {
-		// This one can be optional, maybe just a warning
-		log.WithError(err).Warn("Could not read body.txt, proceeding without it")
+		// Optional: the mailer generates plain text from the HTML without it.
+		if !errors.Is(err, fs.ErrNotExist) {
+			log.WithError(err).Warn("Could not read body.txt, proceeding without it")
+		}
 		bodyText = ""
 	}
 
-	// Meta.json is useful for validation but not strictly required by the mailer
-	// You could extend this to parse and use the metadata
-	_, err = r.readFile(filepath.Join(templateDir, "meta.json"))
+	// Meta.json declares required variables and defaults. A template without
+	// one is still usable, but a malformed one is an error.
+	var meta repository.TemplateMeta
+	rawMeta, err := r.readLocalized(name, chain, "meta.json")
+	if err != nil {
+		log.WithError(err).Warn("Could not read meta.json, proceeding without variable validation")
+	} else if err := json.Unmarshal([]byte(rawMeta), &meta); err != nil {
+		log.WithError(err).Error("Failed to parse meta.json")
+		return nil, fmt.Errorf("could not parse meta.json for template %s: %w", name, err)
+	}
+
+	if meta.From != "" {
+		if _, err := mail.ParseAddress(meta.From); err != nil {
+			return nil, fmt.Errorf("template %s: invalid from address %q: %w", name, meta.From, err)
+		}
+	}
+
+	var layout string
+	if meta.Layout != "" {
+		if meta.Layout != filepath.Base(meta.Layout) || strings.HasPrefix(meta.Layout, ".") {
+			return nil, fmt.Errorf("template %s: invalid layout name %q", name, meta.Layout)
+		}
+		layout, err = r.readFile(filepath.Join(r.basePath, layoutsDir, meta.Layout+".html"))
+		if err != nil {
+			log.WithError(err).Error("Failed to read layout")
+			return nil, err
+		}
+	}
+
+	partials, err := r.loadPartials()
 	if err != nil {
-		log.WithError(err).Warn("Could not read meta.json")
+		log.WithError(err).Error("Failed to read partials")
+		return nil, err
 	}
 
 	return &repository.Template{
-		Name:     name,
-		Subject:  subject,
-		BodyHTML: bodyHTML,
-		BodyText: bodyText,
+		Name:        name,
+		Locale:      chain[0],
+		Subject:     subject,
+		BodyHTML:    bodyHTML,
+		BodyText:    bodyText,
+		BodySMS:     bodySMS,
+		BodyWebhook: bodyWebhook,
+		BodyChat:    bodyChat,
+		BodyInApp:   bodyInApp,
+		Meta:        meta,
+		Layout:      layout,
+		Partials:    partials,
 	}, nil
 }
 
+// loadPartials reads every partials/<name>.html, keyed by name. A missing
+// partials directory simply means there are none.
+func (r *FileTemplateRepo) loadPartials() (map[string]string, error) {
+	paths, err := filepath.Glob(filepath.Join(r.basePath, partialsDir, "*.html"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	partials := make(map[string]string, len(paths))
+	for _, path := range paths {
+		content, err := r.readFile(path)
+		if err != nil {
+			return nil, err
+		}
+		partials[strings.TrimSuffix(filepath.Base(path), ".html")] = content
+	}
+	return partials, nil
+}
+
+// readLocalized reads file from the first locale in chain that has it.
+func (r *FileTemplateRepo) readLocalized(name string, chain []string, file string) (string, error) {
+	var err error
+	for _, locale := range chain {
+		var content string
+		content, err = r.readFile(r.templateFile(name, locale, file))
+		if err == nil || !errors.Is(err, fs.ErrNotExist) {
+			return content, err
+		}
+	}
+	return "", err
+}
+
+func (r *FileTemplateRepo) templateFile(name, locale, file string) string {
+	return filepath.Join(r.basePath, name, locale, file)
+}
+
 func (r *FileTemplateRepo) readFile(path string) (string, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -0,0 +1,235 @@
+package routing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+
+	"notification-service/internal/core/repository"
+)
+
+// Route maps a Kafka topic to the template and channel used to deliver it.
+// A topic may have one route per channel: the first is its primary route,
+// whose recipient comes from the payload, and the others post to a fixed
+// recipient in To, such as a Slack webhook.
+type Route struct {
+	Topic    string   `json:"topic"`
+	Template string   `json:"template"`
+	Channel  string   `json:"channel"`
+	Required []string `json:"required"`
+	// To is the recipient for every event on the route, e.g. "ops" for a
+	// chat webhook. Empty means the channel's payload field holds it.
+	To string `json:"to,omitempty"`
+}
+
+// Table is the declarative topic-to-template routing table.
+type Table struct {
+	routes map[string][]Route
+	topics []string
+}
+
+type tableFile struct {
+	Routes []Route `json:"routes"`
+}
+
+// Load reads the routing table from a JSON file.
+func Load(path string) (*Table, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read routing table %s: %w", path, err)
+	}
+
+	var file tableFile
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return nil, fmt.Errorf("could not parse routing table %s: %w", path, err)
+	}
+
+	return NewTable(file.Routes)
+}
+
+// NewTable builds a routing table, rejecting empty fields, a channel routed
+// twice for the same topic, secondary routes without a fixed recipient and
+// a template routed from two topics, which would make TopicFor ambiguous.
+func NewTable(routes []Route) (*Table, error) {
+	t := &Table{routes: make(map[string][]Route, len(routes))}
+	templateTopics := make(map[string]string)
+	for i, route := range routes {
+		if route.Topic == "" {
+			return nil, fmt.Errorf("route %d: topic is required", i)
+		}
+		if route.Template == "" {
+			return nil, fmt.Errorf("route %q: template is required", route.Topic)
+		}
+		if route.Channel == "" {
+			route.Channel = repository.ChannelEmail
+		}
+		existing := t.routes[route.Topic]
+		if len(existing) == 0 {
+			t.topics = append(t.topics, route.Topic)
+		} else if route.To == "" {
+			return nil, fmt.Errorf("route %q: %s route needs a fixed \"to\", as it is not the topic's first route", route.Topic, route.Channel)
+		}
+		for _, other := range existing {
+			if other.Channel == route.Channel {
+				return nil, fmt.Errorf("route %q: duplicate %s route", route.Topic, route.Channel)
+			}
+		}
+		if topic, ok := templateTopics[route.Template]; ok && topic != route.Topic {
+			return nil, fmt.Errorf("route %q: template %q is already routed from %q", route.Topic, route.Template, topic)
+		}
+		templateTopics[route.Template] = route.Topic
+		t.routes[route.Topic] = append(existing, route)
+	}
+	if len(t.routes) == 0 {
+		return nil, errors.New("routing table has no routes")
+	}
+	sort.Strings(t.topics)
+	return t, nil
+}
+
+// SkipDisabled removes the secondary routes over channels that are not
+// enabled and returns them, so an optional fan-out such as a Slack post can
+// stay in routes.json on deployments without that channel. Primary routes
+// are kept for CheckChannels to report.
+func (t *Table) SkipDisabled(channels []string) []Route {
+	var skipped []Route
+	for _, topic := range t.topics {
+		routes := t.routes[topic]
+		kept := routes[:1]
+		for _, route := range routes[1:] {
+			if slices.Contains(channels, route.Channel) {
+				kept = append(kept, route)
+			} else {
+				skipped = append(skipped, route)
+			}
+		}
+		t.routes[topic] = kept
+	}
+	return skipped
+}
+
+// CheckChannels returns an error for every route over a channel that is
+// not enabled.
+func (t *Table) CheckChannels(channels []string) error {
+	var errs []error
+	for _, topic := range t.topics {
+		for _, route := range t.routes[topic] {
+			if !slices.Contains(channels, route.Channel) {
+				errs = append(errs, fmt.Errorf("route %q: unsupported channel %q", topic, route.Channel))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// CheckTemplates checks every route against the template repository,
+// returning all problems at once: missing templates and templates without
+// a body for the route's channel.
+func (t *Table) CheckTemplates(ctx context.Context, templates repository.TemplateRepository) error {
+	var errs []error
+	for _, topic := range t.topics {
+		for _, route := range t.routes[topic] {
+			template, err := templates.GetTemplate(ctx, route.Template, "")
+			if err != nil {
+				errs = append(errs, fmt.Errorf("route %q: template %q: %w", topic, route.Template, err))
+				continue
+			}
+			if !HasBody(template, route.Channel) {
+				errs = append(errs, fmt.Errorf("route %q: template %q has no %s body", topic, route.Template, route.Channel))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// HasBody reports whether the template can be sent over channel.
+func HasBody(template *repository.Template, channel string) bool {
+	switch channel {
+	case repository.ChannelEmail:
+		return template.BodyHTML != ""
+	case repository.ChannelSMS:
+		return template.BodySMS != ""
+	case repository.ChannelWebhook:
+		return template.BodyWebhook != ""
+	case repository.ChannelChat:
+		return template.BodyChat != ""
+	case repository.ChannelInApp:
+		return template.BodyInApp != ""
+	}
+	return false
+}
+
+// Lookup returns the routes registered for a topic, primary route first.
+func (t *Table) Lookup(topic string) ([]Route, bool) {
+	routes, ok := t.routes[topic]
+	return routes, ok
+}
+
+// Deliveries returns the routes an event on topic goes out over. Without a
+// channel that is every route of the topic. With one, it is that channel's
+// route alone; a channel the topic has no route for, as requested by a
+// gRPC caller, reuses the primary route's template and payload recipient.
+func (t *Table) Deliveries(topic, channel string) ([]Route, bool) {
+	routes, ok := t.routes[topic]
+	if !ok || channel == "" {
+		return routes, ok
+	}
+	for _, route := range routes {
+		if route.Channel == channel {
+			return []Route{route}, true
+		}
+	}
+	route := routes[0]
+	route.Channel = channel
+	route.To = ""
+	return []Route{route}, true
+}
+
+// TopicFor returns the topic routed to the given template.
+func (t *Table) TopicFor(templateName string) (string, bool) {
+	for _, topic := range t.topics {
+		for _, route := range t.routes[topic] {
+			if route.Template == templateName {
+				return topic, true
+			}
+		}
+	}
+	return "", false
+}
+
+// Topics returns every routed topic in a stable order, for the consumer subscription.
+func (t *Table) Topics() []string {
+	topics := make([]string, len(t.topics))
+	copy(topics, t.topics)
+	return topics
+}
+
+// MissingFields returns the required fields absent or empty in a message
+// payload sent over channel. Another channel's recipient field, such as
+// "email" for an SMS, is not required.
+func (r Route) MissingFields(channel string, data map[string]interface{}) []string {
+	var missing []string
+	for _, field := range r.Required {
+		if isOtherRecipientField(channel, field) {
+			continue
+		}
+		value, ok := data[field]
+		if !ok || value == nil || value == "" {
+			missing = append(missing, field)
+		}
+	}
+	return missing
+}
+
+func isOtherRecipientField(channel, field string) bool {
+	for other, recipientField := range repository.RecipientFields {
+		if other != channel && recipientField == field {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+)
+
+// Endpoint is a registered webhook receiver. Its signing secret is read
+// from the environment variable named by SecretEnv, so the registry file
+// itself holds no secrets.
+type Endpoint struct {
+	Name      string `json:"name"`
+	URL       string `json:"url"`
+	SecretEnv string `json:"secret_env"`
+
+	secret []byte
+}
+
+// Registry maps endpoint names, which notifications address, to receivers.
+type Registry struct {
+	endpoints map[string]Endpoint
+}
+
+type registryFile struct {
+	Endpoints []Endpoint `json:"endpoints"`
+}
+
+// LoadRegistry reads the endpoint registry from a JSON file.
+func LoadRegistry(path string) (*Registry, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read webhook registry %s: %w", path, err)
+	}
+
+	var file registryFile
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return nil, fmt.Errorf("could not parse webhook registry %s: %w", path, err)
+	}
+
+	return NewRegistry(file.Endpoints)
+}
+
+// NewRegistry builds a registry, rejecting duplicate names, URLs that are
+// not absolute http(s) URLs and secrets missing from the environment.
+func NewRegistry(endpoints []Endpoint) (*Registry, error) {
+	r := &Registry{endpoints: make(map[string]Endpoint, len(endpoints))}
+	for i, endpoint := range endpoints {
+		if endpoint.Name == "" {
+			return nil, fmt.Errorf("endpoint %d: name is required", i)
+		}
+		if _, exists := r.endpoints[endpoint.Name]; exists {
+			return nil, fmt.Errorf("endpoint %q: duplicate name", endpoint.Name)
+		}
+		u, err := url.Parse(endpoint.URL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return nil, fmt.Errorf("endpoint %q: url %q must be an absolute http(s) URL", endpoint.Name, endpoint.URL)
+		}
+		if endpoint.SecretEnv == "" {
+			return nil, fmt.Errorf("endpoint %q: secret_env is required", endpoint.Name)
+		}
+		secret := os.Getenv(endpoint.SecretEnv)
+		if secret == "" {
+			return nil, fmt.Errorf("endpoint %q: environment variable %s is not set", endpoint.Name, endpoint.SecretEnv)
+		}
+		endpoint.secret = []byte(secret)
+		r.endpoints[endpoint.Name] = endpoint
+	}
+	return r, nil
+}
+
+// Lookup returns the endpoint registered under name.
+func (r *Registry) Lookup(name string) (Endpoint, bool) {
+	endpoint, ok := r.endpoints[name]
+	return endpoint, ok
+}
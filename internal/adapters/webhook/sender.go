@@ -0,0 +1,99 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"notification-service/internal/core/repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ProviderWebhook is the provider of every webhook delivery; the endpoint
+// name is logged alongside it.
+const ProviderWebhook = "webhook"
+
+// Headers sent with every webhook call.
+const (
+	// HeaderSignature carries "sha256=" and the hex HMAC-SHA256 of
+	// "<timestamp>.<body>", keyed with the endpoint's secret.
+	HeaderSignature = "X-Webhook-Signature"
+	// HeaderTimestamp carries the Unix time the call was signed at, so
+	// receivers can reject replayed requests.
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	// HeaderNotificationID lets receivers drop calls they already handled.
+	HeaderNotificationID = "X-Notification-Id"
+)
+
+// Sender is a WebhookSender that POSTs signed JSON payloads to the
+// endpoints in a Registry.
+type Sender struct {
+	registry *Registry
+	client   *http.Client
+	logger   *logrus.Logger
+	now      func() time.Time
+}
+
+func NewSender(registry *Registry, timeout time.Duration, logger *logrus.Logger) *Sender {
+	return &Sender{
+		registry: registry,
+		client:   &http.Client{Timeout: timeout},
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+// Sign returns the signature header value for payload sent at timestamp.
+func Sign(secret []byte, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *Sender) Send(ctx context.Context, webhook *repository.Webhook) (*repository.SendResult, error) {
+	endpoint, ok := s.registry.Lookup(webhook.Endpoint)
+	if !ok {
+		return nil, fmt.Errorf("%w: no endpoint registered as %q", repository.ErrWebhookRejected, webhook.Endpoint)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(webhook.Payload))
+	if err != nil {
+		return nil, fmt.Errorf("could not build webhook request: %w", err)
+	}
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(endpoint.secret, timestamp, webhook.Payload))
+	if webhook.NotificationID != "" {
+		req.Header.Set(HeaderNotificationID, webhook.NotificationID)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call webhook %q: %w", webhook.Endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		raw, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		return nil, repository.NewStatusError("webhook endpoint", resp.StatusCode, raw, repository.ErrWebhookRejected)
+	}
+	// Drain the body so the connection can be reused.
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+
+	s.logger.WithFields(logrus.Fields{
+		"endpoint":    webhook.Endpoint,
+		"status_code": resp.StatusCode,
+	}).Info("webhook accepted by endpoint")
+	return &repository.SendResult{Provider: ProviderWebhook, ResponseCode: resp.StatusCode}, nil
+}
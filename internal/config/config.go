@@ -8,23 +8,53 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	DBDriver     string
-	DBSource     string
-	KafkaBrokers []string
-	KafkaTopics  []string
-	KafkaGroupID string
-	SmtpHost     string
-	SmtpPort     int
-	SmtpUser     string
-	SmtpPass     string
-	SmtpFrom     string
-	GrpcPort     string
-	TemplatePath string
+	DBDriver               string
+	DBSource               string
+	KafkaBrokers           []string
+	KafkaGroupID           string
+	DLQTopic               string
+	RetryMax               int
+	RetryInitial           time.Duration
+	RetryMaxWait           time.Duration
+	IdempotencyTTL         time.Duration
+	AttachmentDir          string
+	AttachmentMaxInline    int64
+	AttachmentMaxTotal     int64
+	SmtpHost               string
+	SmtpPort               int
+	SmtpUser               string
+	SmtpPass               string
+	SmtpFrom               string
+	SmtpPoolSize           int
+	SmtpIdleTimeout        time.Duration
+	EmailProviders         []string
+	EmailFailoverThreshold int
+	EmailFailoverCooldown  time.Duration
+	EmailHTTPEndpoint      string
+	EmailHTTPAPIKey        string
+	EmailHTTPTimeout       time.Duration
+	SmsHTTPEndpoint        string
+	SmsHTTPAccountID       string
+	SmsHTTPAuthToken       string
+	SmsHTTPTimeout         time.Duration
+	SmsFrom                string
+	SmsMaxSegments         int
+	WebhooksPath           string
+	WebhookTimeout         time.Duration
+	ChatWebhooksPath       string
+	ChatTimeout            time.Duration
+	ChatMinInterval        time.Duration
+	GrpcPort               string
+	TemplatePath           string
+	TemplateSource         string
+	TemplatePoll           time.Duration
+	RoutesPath             string
 }
 
 func LoadConfig() *Config {
@@ -43,6 +73,13 @@ func LoadConfig() *Config {
 	// --- END: Robust .env loading ---
 
 	smtpPort, _ := strconv.Atoi(getEnv("SMTP_PORT", "587"))
+	retryMax, _ := strconv.Atoi(getEnv("RETRY_MAX_ATTEMPTS", "5"))
+	smtpPoolSize, _ := strconv.Atoi(getEnv("SMTP_POOL_SIZE", "4"))
+	failoverThreshold, _ := strconv.Atoi(getEnv("EMAIL_FAILOVER_THRESHOLD", "3"))
+	smsMaxSegments, _ := strconv.Atoi(getEnv("SMS_MAX_SEGMENTS", "3"))
+	// Inline attachments travel through Kafka, whose default message limit is 1MB.
+	attachmentMaxInline, _ := strconv.ParseInt(getEnv("ATTACHMENT_MAX_INLINE_BYTES", "524288"), 10, 64)
+	attachmentMaxTotal, _ := strconv.ParseInt(getEnv("ATTACHMENT_MAX_TOTAL_BYTES", "10485760"), 10, 64)
 
 	// Construct the database source string from individual env vars
 	dbSource := fmt.Sprintf("postgresql://%s:%s@%s:%s/%s?sslmode=%s",
@@ -55,23 +92,48 @@ func LoadConfig() *Config {
 	)
 
 	return &Config{
-		DBDriver:     getEnv("DB_DRIVER", "postgres"),
-		DBSource:     dbSource,
-		KafkaBrokers: strings.Split(getEnv("KAFKA_BROKERS", "localhost:9092"), ","),
-		KafkaTopics: []string{
-			"notification.send-welcome",
-			"notification.send-signup-verification",
-			"notification.provisioning-started",
-			"notification.send-password-setup",
-		},
-		KafkaGroupID: getEnv("KAFKA_GROUP_ID", "notification-group"),
-		SmtpHost:     getEnv("SMTP_HOST", "smtp.gmail.com"),
-		SmtpPort:     smtpPort,
-		SmtpUser:     getEnv("SMTP_USER", ""),
-		SmtpPass:     getEnv("SMTP_PASS", ""),
-		SmtpFrom:     getEnv("SMTP_FROM_EMAIL", "your-email@example.com"),
-		GrpcPort:     getEnv("GRPC_PORT", "50051"),
-		TemplatePath: getEnv("TEMPLATE_PATH", "internal/adapters/templates/emails"),
+		DBDriver:            getEnv("DB_DRIVER", "postgres"),
+		DBSource:            dbSource,
+		KafkaBrokers:        strings.Split(getEnv("KAFKA_BROKERS", "localhost:9092"), ","),
+		KafkaGroupID:        getEnv("KAFKA_GROUP_ID", "notification-group"),
+		DLQTopic:            getEnv("DLQ_TOPIC", "notification.dlq"),
+		RetryMax:            retryMax,
+		RetryInitial:        getEnvDuration("RETRY_INITIAL_BACKOFF", time.Second),
+		RetryMaxWait:        getEnvDuration("RETRY_MAX_BACKOFF", 30*time.Second),
+		IdempotencyTTL:      getEnvDuration("IDEMPOTENCY_TTL", 24*time.Hour),
+		AttachmentDir:       getEnv("ATTACHMENT_DIR", ""),
+		AttachmentMaxInline: attachmentMaxInline,
+		AttachmentMaxTotal:  attachmentMaxTotal,
+		SmtpHost:            getEnv("SMTP_HOST", "smtp.gmail.com"),
+		SmtpPort:            smtpPort,
+		SmtpUser:            getEnv("SMTP_USER", ""),
+		SmtpPass:            getEnv("SMTP_PASS", ""),
+		SmtpFrom:            getEnv("SMTP_FROM_EMAIL", "your-email@example.com"),
+		SmtpPoolSize:        smtpPoolSize,
+		SmtpIdleTimeout:     getEnvDuration("SMTP_IDLE_TIMEOUT", 30*time.Second),
+		// An ordered list: later providers take over while earlier ones fail.
+		EmailProviders:         strings.Split(getEnv("EMAIL_PROVIDER", "smtp"), ","),
+		EmailFailoverThreshold: failoverThreshold,
+		EmailFailoverCooldown:  getEnvDuration("EMAIL_FAILOVER_COOLDOWN", 30*time.Second),
+		EmailHTTPEndpoint:      getEnv("EMAIL_HTTP_ENDPOINT", ""),
+		EmailHTTPAPIKey:        getEnv("EMAIL_HTTP_API_KEY", ""),
+		EmailHTTPTimeout:       getEnvDuration("EMAIL_HTTP_TIMEOUT", 10*time.Second),
+		SmsHTTPEndpoint:        getEnv("SMS_HTTP_ENDPOINT", ""),
+		SmsHTTPAccountID:       getEnv("SMS_HTTP_ACCOUNT_ID", ""),
+		SmsHTTPAuthToken:       getEnv("SMS_HTTP_AUTH_TOKEN", ""),
+		SmsHTTPTimeout:         getEnvDuration("SMS_HTTP_TIMEOUT", 10*time.Second),
+		SmsFrom:                getEnv("SMS_FROM", ""),
+		SmsMaxSegments:         smsMaxSegments,
+		WebhooksPath:           getEnv("WEBHOOKS_PATH", ""),
+		WebhookTimeout:         getEnvDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+		ChatWebhooksPath:       getEnv("CHAT_WEBHOOKS_PATH", ""),
+		ChatTimeout:            getEnvDuration("CHAT_TIMEOUT", 10*time.Second),
+		ChatMinInterval:        getEnvDuration("CHAT_MIN_INTERVAL", time.Second),
+		GrpcPort:               getEnv("GRPC_PORT", "50051"),
+		TemplatePath:           getEnv("TEMPLATE_PATH", "internal/adapters/templates/emails"),
+		TemplateSource:         getEnv("TEMPLATE_SOURCE", "file"),
+		TemplatePoll:           getEnvDuration("TEMPLATE_RELOAD_INTERVAL", 5*time.Second),
+		RoutesPath:             getEnv("ROUTES_PATH", "internal/adapters/templates/routes.json"),
 	}
 }
 
@@ -81,3 +143,16 @@ func getEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("Warning: invalid duration %q for %s, using %s", value, key, fallback)
+		return fallback
+	}
+	return d
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrBlobNotFound is returned by a BlobStore for paths that do not exist.
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore is the port for reading attachment content stored out of band,
+// so large files do not have to travel through Kafka.
+type BlobStore interface {
+	// Size returns the size in bytes of the blob at path.
+	Size(ctx context.Context, path string) (int64, error)
+	Read(ctx context.Context, path string) ([]byte, error)
+}
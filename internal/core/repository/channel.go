@@ -0,0 +1,22 @@
+package repository
+
+// Delivery channels a notification can be sent over.
+const (
+	ChannelEmail   = "email"
+	ChannelSMS     = "sms"
+	ChannelWebhook = "webhook"
+	ChannelChat    = "chat"
+	ChannelInApp   = "inapp"
+)
+
+// RecipientFields maps each channel to the payload key holding its
+// recipient: the email address, the E.164 phone number, or the name of a
+// registered webhook endpoint or chat webhook, or the ERP user ID whose
+// inbox an in-app notification goes to.
+var RecipientFields = map[string]string{
+	ChannelEmail:   "email",
+	ChannelSMS:     "phone",
+	ChannelWebhook: "webhook",
+	ChannelChat:    "chat",
+	ChannelInApp:   "user_id",
+}
@@ -0,0 +1,17 @@
+package repository
+
+import "context"
+
+// ChatMessage is a rendered chat message, such as a Slack Block Kit
+// document, for a registered incoming webhook.
+type ChatMessage struct {
+	// Webhook is the name the incoming webhook is registered under.
+	Webhook string
+	Payload []byte
+}
+
+// ChatSender is the port for posting messages to chat incoming webhooks.
+// Non-2xx answers are reported as *StatusError.
+type ChatSender interface {
+	Send(ctx context.Context, message *ChatMessage) (*SendResult, error)
+}
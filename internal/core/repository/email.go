@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrEmailRejected marks emails a provider refused outright, e.g. for an
+// invalid address; sending them again cannot succeed.
+var ErrEmailRejected = errors.New("email rejected by provider")
+
+// ErrProviderAuth marks a provider refusing the service's credentials. It
+// says nothing about the message, so another provider, or the same one once
+// its configuration is fixed, may still deliver it.
+var ErrProviderAuth = errors.New("provider refused credentials")
+
+// Email is a fully rendered message ready to hand to a provider.
+type Email struct {
+	// From overrides the sender's default address when set.
+	From        string
+	To          []string
+	Cc          []string
+	Bcc         []string
+	ReplyTo     []string
+	Subject     string
+	HTML        string
+	Text        string
+	Attachments []EmailAttachment
+}
+
+// EmailAttachment is a file sent with an email. With a ContentID it is
+// embedded inline instead, for the HTML to reference as "cid:<ContentID>".
+type EmailAttachment struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+	ContentID   string
+}
+
+// SendResult identifies a delivered message at the provider.
+type SendResult struct {
+	Provider string
+	// MessageID is the provider's ID for the message, if it returns one.
+	MessageID string
+	// ResponseCode is the HTTP status a webhook receiver answered with.
+	ResponseCode int
+}
+
+// EmailSender is the port for handing rendered emails to a delivery provider.
+type EmailSender interface {
+	Send(ctx context.Context, email *Email) (*SendResult, error)
+}
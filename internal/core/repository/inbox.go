@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"notification-service/internal/adapters/database/db"
+)
+
+// InboxRepository is the port for the per-user inbox of in-app notifications.
+type InboxRepository interface {
+	// Create stores a notification and reports false, without error, if the
+	// user's inbox already holds its notification ID.
+	Create(ctx context.Context, params db.CreateInboxNotificationParams) (db.InboxNotification, bool, error)
+	Get(ctx context.Context, id int64) (db.InboxNotification, error)
+	List(ctx context.Context, params db.ListInboxNotificationsParams) ([]db.InboxNotification, error)
+	CountUnread(ctx context.Context, userID string) (int64, error)
+	// MarkRead and MarkAllRead return how many unread notifications they marked.
+	MarkRead(ctx context.Context, userID string, ids []int64, at time.Time) (int64, error)
+	MarkAllRead(ctx context.Context, userID string, at time.Time) (int64, error)
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"regexp"
+	"strings"
+)
+
+// localePattern accepts BCP 47 style tags such as "de", "de-DE" or "zh-Hant-TW".
+var localePattern = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{2,8})*$`)
+
+// NormalizeLocale canonicalises a locale tag ("de_de" -> "de-DE") and reports
+// whether it is well formed. The empty locale is valid and means "default".
+func NormalizeLocale(locale string) (string, bool) {
+	locale = strings.ReplaceAll(strings.TrimSpace(locale), "_", "-")
+	if locale == "" {
+		return "", true
+	}
+	if !localePattern.MatchString(locale) {
+		return "", false
+	}
+
+	parts := strings.Split(locale, "-")
+	parts[0] = strings.ToLower(parts[0])
+	for i := 1; i < len(parts); i++ {
+		switch len(parts[i]) {
+		case 2:
+			parts[i] = strings.ToUpper(parts[i]) // region, e.g. DE
+		case 4:
+			parts[i] = strings.ToUpper(parts[i][:1]) + strings.ToLower(parts[i][1:]) // script, e.g. Hant
+		}
+	}
+	return strings.Join(parts, "-"), true
+}
+
+// LocaleFallbacks returns the lookup order for a locale, most specific first
+// and always ending with the default (""): "de-DE" -> ["de-DE", "de", ""].
+// Malformed locales resolve straight to the default.
+func LocaleFallbacks(locale string) []string {
+	locale, ok := NormalizeLocale(locale)
+	if !ok || locale == "" {
+		return []string{""}
+	}
+
+	parts := strings.Split(locale, "-")
+	chain := make([]string, 0, len(parts)+1)
+	for i := len(parts); i > 0; i-- {
+		chain = append(chain, strings.Join(parts[:i], "-"))
+	}
+	return append(chain, "")
+}
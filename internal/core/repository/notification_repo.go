@@ -2,24 +2,96 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"notification-service/internal/adapters/database/db"
 )
 
 // Template represents a parsed email template.
 type Template struct {
-	Name     string
+	Name string
+	// Version is the stored version number, or 0 for file-based templates.
+	Version int
+	// Locale is the locale variant that was resolved, or "" for the default.
+	Locale   string
 	Subject  string
 	BodyHTML string
 	BodyText string
+	// BodySMS is the text/template source of the SMS variant, if any.
+	BodySMS string
+	// BodyWebhook is the text/template source of the JSON webhook payload.
+	BodyWebhook string
+	// BodyChat is the text/template source of a Slack Block Kit message.
+	BodyChat string
+	// BodyInApp is the text/template source of an in-app inbox entry,
+	// titled with the rendered subject.
+	BodyInApp string
+	Meta      TemplateMeta
+	// Layout is the source of the layout named in Meta.Layout, if any. It
+	// wraps BodyHTML, which it renders with {{template "content" .}}.
+	Layout string
+	// Partials are shared named templates, by name, available to BodyHTML
+	// and Layout through {{template "name" .}}.
+	Partials map[string]string
 }
 
 // TemplateRepository is the port for fetching notification templates.
+// Implementations resolve the locale through LocaleFallbacks, so a template
+// always comes back in the closest available locale.
 type TemplateRepository interface {
-	GetTemplate(ctx context.Context, name string) (*Template, error)
+	GetTemplate(ctx context.Context, name, locale string) (*Template, error)
+}
+
+// TemplateStore is the port for managing versioned templates. Templates are
+// keyed by name and exact locale ("" is the default variant); no fallback is
+// applied here. Version 0 means "the active version" wherever a version is
+// looked up.
+type TemplateStore interface {
+	CreateTemplate(ctx context.Context, tmpl *Template, activate bool) (*TemplateSummary, *Template, error)
+	AddVersion(ctx context.Context, tmpl *Template, activate bool) (*TemplateSummary, *Template, error)
+	ListTemplates(ctx context.Context) ([]TemplateSummary, error)
+	GetTemplateSummary(ctx context.Context, name, locale string) (*TemplateSummary, error)
+	GetVersion(ctx context.Context, name, locale string, version int) (*Template, error)
+	ActivateVersion(ctx context.Context, name, locale string, version int) (*TemplateSummary, error)
+	DeleteTemplate(ctx context.Context, name, locale string) error
 }
 
 // NotificationLogRepository is the port for logging notification attempts.
 type NotificationLogRepository interface {
 	CreateLog(ctx context.Context, params db.CreateNotificationLogParams) error
+	ListFailedLogs(ctx context.Context, params db.ListFailedNotificationLogsParams) ([]db.NotificationLog, error)
+	MarkReplayed(ctx context.Context, id int32, at time.Time) error
+	GetLog(ctx context.Context, id int32) (db.NotificationLog, error)
+	GetLatestLogByNotificationID(ctx context.Context, notificationID string) (db.NotificationLog, error)
+	ListLogs(ctx context.Context, params db.ListNotificationLogsParams) ([]db.NotificationLog, error)
+}
+
+// IdempotencyClaim is the outcome of claiming an idempotency key.
+type IdempotencyClaim int
+
+const (
+	// ClaimAcquired means the caller holds the key's lease and may send.
+	ClaimAcquired IdempotencyClaim = iota
+	// ClaimDone means the notification was already delivered.
+	ClaimDone
+	// ClaimPending means another attempt holds an unexpired lease.
+	ClaimPending
+)
+
+// IdempotencyRepository is the port for remembering which idempotency keys
+// have already been handled.
+type IdempotencyRepository interface {
+	// Claim takes the key as pending for lease, unless it is done or
+	// another attempt's lease on it has not run out.
+	Claim(ctx context.Context, key, notificationID string, lease time.Duration) (IdempotencyClaim, error)
+	// Complete marks a claimed key as delivered and keeps it for ttl.
+	Complete(ctx context.Context, key, notificationID string, ttl time.Duration) error
+	// Release drops a pending claim so a failed notification can be tried again.
+	Release(ctx context.Context, key, notificationID string) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+// EventPublisher is the port for enqueueing notification events.
+type EventPublisher interface {
+	Publish(ctx context.Context, topic string, data map[string]interface{}, headers map[string]string) error
 }
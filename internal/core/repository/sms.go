@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrSMSRejected marks text messages a provider refused outright, e.g. for
+// an unreachable number or bad credentials; sending again cannot succeed.
+var ErrSMSRejected = errors.New("sms rejected by provider")
+
+// SMS is a rendered text message ready to hand to a provider.
+type SMS struct {
+	// To is an E.164 phone number, e.g. "+14155550123".
+	To   string
+	Body string
+}
+
+// SMSSender is the port for handing text messages to a delivery provider.
+type SMSSender interface {
+	Send(ctx context.Context, sms *SMS) (*SendResult, error)
+}
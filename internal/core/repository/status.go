@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// maxErrorBody caps how much of an error response a StatusError keeps.
+const maxErrorBody = 2048
+
+// StatusError is returned by the HTTP adapters when the provider or
+// receiver answers with a non-2xx status. 408, 429 and 5xx answers are
+// worth retrying and any other 4xx rejects the message, except that 401
+// and 403 from a provider mean the service's credentials were refused.
+type StatusError struct {
+	// Service names the other side in the message, e.g. "sms provider".
+	Service    string
+	StatusCode int
+	Body       string
+	// Rejected is the channel's rejection error, e.g. ErrSMSRejected.
+	Rejected error
+	// Provider marks answers from a delivery provider the service holds
+	// credentials for, rather than from a receiver such as a webhook.
+	Provider bool
+}
+
+// NewStatusError is for receivers, such as webhook endpoints, whose 401 and
+// 403 answers reject the message like any other 4xx. It keeps at most the
+// first 2KB of body for the log.
+func NewStatusError(service string, statusCode int, body []byte, rejected error) *StatusError {
+	if len(body) > maxErrorBody {
+		body = body[:maxErrorBody]
+	}
+	return &StatusError{Service: service, StatusCode: statusCode, Body: string(body), Rejected: rejected}
+}
+
+// NewProviderStatusError is for delivery providers, whose 401 and 403
+// answers are ErrProviderAuth.
+func NewProviderStatusError(service string, statusCode int, body []byte, rejected error) *StatusError {
+	e := NewStatusError(service, statusCode, body, rejected)
+	e.Provider = true
+	return e
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s returned %d: %s", e.Service, e.StatusCode, e.Body)
+}
+
+func (e *StatusError) Unwrap() error {
+	switch {
+	case e.Provider && (e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden):
+		return ErrProviderAuth
+	case e.StatusCode == http.StatusRequestTimeout || e.StatusCode == http.StatusTooManyRequests:
+		return nil
+	case e.StatusCode >= 400 && e.StatusCode < 500:
+		return e.Rejected
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrTemplateNotFound is returned by a TemplateRepository for unknown templates.
+	ErrTemplateNotFound = errors.New("template not found")
+	// ErrTemplateExists is returned when creating a template whose name is taken.
+	ErrTemplateExists = errors.New("template already exists")
+	// ErrTemplateVersionNotFound is returned for an unknown version of a known template.
+	ErrTemplateVersionNotFound = errors.New("template version not found")
+)
+
+// BuiltinVariables are supplied to every template by the service itself:
+// the recipient address, phone number, webhook endpoint, chat webhook or
+// inbox user ID and the rendered subject line.
+var BuiltinVariables = []string{"email", "phone", "webhook", "chat", "user_id", "subject"}
+
+// TemplateSummary describes a stored template and its versions.
+type TemplateSummary struct {
+	Name          string
+	Locale        string
+	ActiveVersion int
+	LatestVersion int
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// TemplateMeta mirrors a template's meta.json.
+type TemplateMeta struct {
+	Description string                 `json:"description"`
+	Variables   TemplateVariables      `json:"variables"`
+	Defaults    map[string]interface{} `json:"defaults"`
+	// Layout names a layout in the template root's layouts/ directory.
+	Layout string `json:"layout,omitempty"`
+	// InlineCSS moves the <style> rules of the rendered HTML into style
+	// attributes, for clients that strip stylesheets.
+	InlineCSS bool `json:"inline_css,omitempty"`
+	// From overrides the service's sender address for this template,
+	// e.g. "Billing <billing@example.com>".
+	From string `json:"from,omitempty"`
+}
+
+// TemplateVariables lists the data keys a template expects.
+type TemplateVariables struct {
+	Required []string `json:"required"`
+	Optional []string `json:"optional"`
+}
+
+// MissingVariablesError is returned when required template variables are absent.
+type MissingVariablesError struct {
+	Template string
+	Missing  []string
+}
+
+func (e *MissingVariablesError) Error() string {
+	return fmt.Sprintf("template %q is missing required variables: %s", e.Template, strings.Join(e.Missing, ", "))
+}
+
+// PrepareData merges the template defaults into data and checks that every
+// required variable is present. Values in data win over defaults; the
+// input map is not modified. The merged map is returned even when variables
+// are missing, so previews can still render it.
+func (t *Template) PrepareData(data map[string]interface{}) (map[string]interface{}, error) {
+	merged := make(map[string]interface{}, len(t.Meta.Defaults)+len(data))
+	for key, value := range t.Meta.Defaults {
+		merged[key] = value
+	}
+	for key, value := range data {
+		if isEmptyValue(value) {
+			if _, hasDefault := t.Meta.Defaults[key]; hasDefault {
+				continue
+			}
+		}
+		merged[key] = value
+	}
+
+	var missing []string
+	for _, key := range t.Meta.Variables.Required {
+		if value, ok := merged[key]; !ok || isEmptyValue(value) {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return merged, &MissingVariablesError{Template: t.Name, Missing: missing}
+	}
+	return merged, nil
+}
+
+func isEmptyValue(value interface{}) bool {
+	return value == nil || value == ""
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrWebhookRejected marks webhook calls, including chat webhooks, that
+// cannot succeed if repeated: an unknown endpoint or a 4xx answer other
+// than 408 and 429.
+var ErrWebhookRejected = errors.New("webhook rejected")
+
+// Webhook is a rendered JSON payload for a registered endpoint.
+type Webhook struct {
+	// Endpoint is the name the endpoint is registered under, not its URL.
+	Endpoint       string
+	NotificationID string
+	Payload        []byte
+}
+
+// WebhookSender is the port for delivering payloads to registered endpoints.
+type WebhookSender interface {
+	Send(ctx context.Context, webhook *Webhook) (*SendResult, error)
+}
@@ -0,0 +1,234 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"notification-service/internal/core/repository"
+)
+
+// PayloadAttachments is the Kafka payload key holding a notification's
+// attachments. It is stripped from the data before templates see it.
+const PayloadAttachments = "attachments"
+
+// ErrInvalidAttachment marks attachments that can never be sent as given.
+var ErrInvalidAttachment = errors.New("invalid attachment")
+
+// Attachment is a file sent with a notification. Exactly one of Content or
+// BlobPath is set; Content is base64 encoded in the Kafka payload.
+type Attachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type,omitempty"`
+	Content     []byte `json:"content,omitempty"`
+	BlobPath    string `json:"blob_path,omitempty"`
+	// ContentID embeds the attachment inline for the HTML body to reference
+	// as <img src="cid:...">.
+	ContentID string `json:"content_id,omitempty"`
+}
+
+// AttachmentLimits bound what a single notification may carry. Inline
+// content travels through Kafka, so it is held to a much lower limit than
+// blobs, which are only read when the email is sent.
+type AttachmentLimits struct {
+	MaxInlineBytes int64
+	MaxTotalBytes  int64
+}
+
+// AttachmentResolver validates attachments and loads their content.
+type AttachmentResolver struct {
+	blobs  repository.BlobStore
+	limits AttachmentLimits
+}
+
+// NewAttachmentResolver creates a resolver. blobs may be nil, in which case
+// attachments referring to a blob path are rejected.
+func NewAttachmentResolver(blobs repository.BlobStore, limits AttachmentLimits) *AttachmentResolver {
+	return &AttachmentResolver{
+		blobs:  blobs,
+		limits: limits,
+	}
+}
+
+// ExtractAttachments removes the attachments from a Kafka payload and
+// returns them together with the remaining template data. data itself is
+// not modified.
+func ExtractAttachments(data map[string]interface{}) ([]Attachment, map[string]interface{}, error) {
+	raw, ok := data[PayloadAttachments]
+	if !ok {
+		return nil, data, nil
+	}
+
+	rest := make(map[string]interface{}, len(data)-1)
+	for key, value := range data {
+		if key != PayloadAttachments {
+			rest[key] = value
+		}
+	}
+	if raw == nil {
+		return nil, rest, nil
+	}
+
+	// The payload was decoded into generic values; round-trip the field to
+	// get the typed form back.
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return nil, rest, fmt.Errorf("%w: %v", ErrInvalidAttachment, err)
+	}
+	var attachments []Attachment
+	if err := json.Unmarshal(encoded, &attachments); err != nil {
+		return nil, rest, fmt.Errorf("%w: %v", ErrInvalidAttachment, err)
+	}
+	return attachments, rest, nil
+}
+
+// loggedAttachment is what notification_logs keeps of an attachment. Inline
+// content is left out so files are not copied into every log row; a blob
+// path is kept, so a replay can read the blob again.
+type loggedAttachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type,omitempty"`
+	BlobPath    string `json:"blob_path,omitempty"`
+	ContentID   string `json:"content_id,omitempty"`
+	Size        int    `json:"size,omitempty"`
+}
+
+// logData returns data as it should be logged, with the attachments' inline
+// content replaced by its size. Malformed attachments are dropped; why they
+// failed is in the log's details. data itself is not modified.
+func logData(data map[string]interface{}) map[string]interface{} {
+	attachments, rest, err := ExtractAttachments(data)
+	if err != nil || len(attachments) == 0 {
+		return rest
+	}
+	logged := make([]loggedAttachment, 0, len(attachments))
+	for _, a := range attachments {
+		logged = append(logged, loggedAttachment{
+			Filename:    a.Filename,
+			ContentType: a.ContentType,
+			BlobPath:    a.BlobPath,
+			ContentID:   a.ContentID,
+			Size:        len(a.Content),
+		})
+	}
+	rest[PayloadAttachments] = logged
+	return rest
+}
+
+// droppedAttachment returns the name of the first attachment in logged data
+// whose inline content logData left out, and which a replay cannot send.
+func droppedAttachment(data map[string]interface{}) (string, bool) {
+	attachments, _, err := ExtractAttachments(data)
+	if err != nil {
+		return "", false
+	}
+	for _, a := range attachments {
+		if a.BlobPath == "" {
+			return a.Filename, true
+		}
+	}
+	return "", false
+}
+
+// Validate checks attachments against the limits without reading blobs.
+func (r *AttachmentResolver) Validate(ctx context.Context, attachments []Attachment) error {
+	var inline, total int64
+	for i, a := range attachments {
+		if err := r.checkFields(a); err != nil {
+			return fmt.Errorf("attachment %d: %w", i, err)
+		}
+		if a.BlobPath == "" {
+			inline += int64(len(a.Content))
+			total += int64(len(a.Content))
+			continue
+		}
+		size, err := r.blobs.Size(ctx, a.BlobPath)
+		if errors.Is(err, repository.ErrBlobNotFound) {
+			return fmt.Errorf("attachment %d: %w: %v", i, ErrInvalidAttachment, err)
+		}
+		if err != nil {
+			return fmt.Errorf("attachment %d: %w", i, err)
+		}
+		total += size
+	}
+
+	if r.limits.MaxInlineBytes > 0 && inline > r.limits.MaxInlineBytes {
+		return fmt.Errorf("%w: inline attachments are %d bytes, the limit is %d; use a blob path for large files", ErrInvalidAttachment, inline, r.limits.MaxInlineBytes)
+	}
+	if r.limits.MaxTotalBytes > 0 && total > r.limits.MaxTotalBytes {
+		return fmt.Errorf("%w: attachments are %d bytes, the limit is %d", ErrInvalidAttachment, total, r.limits.MaxTotalBytes)
+	}
+	return nil
+}
+
+// Load validates attachments and returns them ready for sending, with
+// blobs read and content types filled in.
+func (r *AttachmentResolver) Load(ctx context.Context, attachments []Attachment) ([]repository.EmailAttachment, error) {
+	if len(attachments) == 0 {
+		return nil, nil
+	}
+	if err := r.Validate(ctx, attachments); err != nil {
+		return nil, err
+	}
+
+	out := make([]repository.EmailAttachment, 0, len(attachments))
+	for i, a := range attachments {
+		content := a.Content
+		if a.BlobPath != "" {
+			var err error
+			if content, err = r.blobs.Read(ctx, a.BlobPath); err != nil {
+				return nil, fmt.Errorf("attachment %d: %w", i, err)
+			}
+		}
+		out = append(out, repository.EmailAttachment{
+			Filename:    a.Filename,
+			ContentType: contentType(a, content),
+			Content:     content,
+			ContentID:   a.ContentID,
+		})
+	}
+	return out, nil
+}
+
+func (r *AttachmentResolver) checkFields(a Attachment) error {
+	if a.Filename == "" || a.Filename != filepath.Base(a.Filename) {
+		return fmt.Errorf("%w: filename %q must be a plain file name", ErrInvalidAttachment, a.Filename)
+	}
+	if (len(a.Content) == 0) == (a.BlobPath == "") {
+		return fmt.Errorf("%w: %s needs exactly one of content or blob_path", ErrInvalidAttachment, a.Filename)
+	}
+	if a.BlobPath != "" {
+		if r.blobs == nil {
+			return fmt.Errorf("%w: %s refers to a blob but no blob store is configured", ErrInvalidAttachment, a.Filename)
+		}
+		if !filepath.IsLocal(filepath.FromSlash(a.BlobPath)) {
+			return fmt.Errorf("%w: blob path %q must be relative to the blob store", ErrInvalidAttachment, a.BlobPath)
+		}
+	}
+	if a.ContentType != "" {
+		if _, _, err := mime.ParseMediaType(a.ContentType); err != nil {
+			return fmt.Errorf("%w: content type %q: %v", ErrInvalidAttachment, a.ContentType, err)
+		}
+	}
+	if strings.ContainsAny(a.ContentID, "<>\r\n ") {
+		return fmt.Errorf("%w: content ID %q must not contain spaces or angle brackets", ErrInvalidAttachment, a.ContentID)
+	}
+	return nil
+}
+
+// contentType returns the declared type, or guesses one from the file
+// extension and then the content itself.
+func contentType(a Attachment, content []byte) string {
+	if a.ContentType != "" {
+		return a.ContentType
+	}
+	if byExt := mime.TypeByExtension(filepath.Ext(a.Filename)); byExt != "" {
+		return byExt
+	}
+	return http.DetectContentType(content)
+}
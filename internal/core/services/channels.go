@@ -0,0 +1,50 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"notification-service/internal/adapters/mailme"
+	"notification-service/internal/core/repository"
+)
+
+// Channels holds the delivery channels besides email. A nil field disables
+// its channel; notifications for it then fail permanently.
+type Channels struct {
+	SMS     *SMSChannel
+	Webhook repository.WebhookSender
+	Chat    repository.ChatSender
+	InApp   *InboxService
+}
+
+// renderText renders a non-HTML body such as an SMS or a JSON payload.
+// text/template is used, so nothing is HTML-escaped.
+func renderText(name, source string, data map[string]interface{}) (string, error) {
+	tmpl, err := template.New(name).Funcs(template.FuncMap(mailme.Funcs())).Parse(source)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", mailme.ErrRender, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("%w: %v", mailme.ErrRender, err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// renderJSON renders a webhook or chat body and checks that the result is
+// JSON. Values should go through the json helper, e.g. {{json .org_name}},
+// so they are quoted and escaped.
+func renderJSON(name, source string, data map[string]interface{}) ([]byte, error) {
+	rendered, err := renderText(name, source, data)
+	if err != nil {
+		return nil, err
+	}
+	var payload bytes.Buffer
+	if err := json.Compact(&payload, []byte(rendered)); err != nil {
+		return nil, fmt.Errorf("%w: %s payload is not valid JSON: %v", mailme.ErrRender, name, err)
+	}
+	return payload.Bytes(), nil
+}
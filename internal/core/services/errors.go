@@ -0,0 +1,62 @@
+package services
+
+import (
+	"errors"
+	"net/textproto"
+
+	"notification-service/internal/adapters/mailme"
+	"notification-service/internal/core/repository"
+)
+
+// DeliveryError is returned by SendNotification and tells the caller whether
+// the same request may succeed if it is tried again.
+type DeliveryError struct {
+	Err       error
+	Transient bool
+}
+
+func (e *DeliveryError) Error() string { return e.Err.Error() }
+func (e *DeliveryError) Unwrap() error { return e.Err }
+
+// Class returns "transient" or "permanent", as used in logs and DLQ headers.
+func (e *DeliveryError) Class() string {
+	if e.Transient {
+		return "transient"
+	}
+	return "permanent"
+}
+
+func transient(err error) error { return &DeliveryError{Err: err, Transient: true} }
+func permanent(err error) error { return &DeliveryError{Err: err, Transient: false} }
+
+// IsTransient reports whether err is a DeliveryError worth retrying.
+func IsTransient(err error) bool {
+	var de *DeliveryError
+	return errors.As(err, &de) && de.Transient
+}
+
+// classifySendError decides whether a sender failure is worth retrying.
+// Rendering errors, provider rejections and 5xx SMTP replies are permanent;
+// 4xx SMTP replies, network failures and anything else are transient.
+// Credential failures are transient too: the provider is at fault, not the
+// message, so failover moves on and its circuit breaker counts them.
+func classifySendError(err error) error {
+	if errors.Is(err, repository.ErrProviderAuth) {
+		return transient(err)
+	}
+	if errors.Is(err, mailme.ErrRender) || errors.Is(err, repository.ErrEmailRejected) ||
+		errors.Is(err, repository.ErrSMSRejected) || errors.Is(err, repository.ErrWebhookRejected) {
+		return permanent(err)
+	}
+
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) {
+		if protoErr.Code >= 500 {
+			return permanent(err)
+		}
+		return transient(err)
+	}
+
+	// Dial, TLS and connection errors all end up here.
+	return transient(err)
+}
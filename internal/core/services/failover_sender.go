@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"notification-service/internal/core/repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrNoEmailProvider is returned when every provider's circuit is open.
+var ErrNoEmailProvider = errors.New("no email provider available")
+
+// NamedSender is one provider in a FailoverSender, in priority order.
+type NamedSender struct {
+	Name   string
+	Sender repository.EmailSender
+}
+
+// BreakerPolicy controls when a provider is taken out of rotation: after
+// Threshold consecutive transient failures its circuit opens, and after
+// Cooldown a single probe is let through to see whether it recovered.
+type BreakerPolicy struct {
+	Threshold int
+	Cooldown  time.Duration
+}
+
+// FailoverSender is an EmailSender that tries an ordered list of providers.
+// A transient failure, including a provider refusing its credentials, moves
+// the email on to the next provider; permanent failures, such as a rejected
+// address, are returned straight away since another provider would reject
+// it too.
+type FailoverSender struct {
+	providers []*provider
+	policy    BreakerPolicy
+	logger    *logrus.Logger
+	now       func() time.Time
+}
+
+type provider struct {
+	NamedSender
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time // zero while the circuit is closed
+	probing  bool
+}
+
+func NewFailoverSender(senders []NamedSender, policy BreakerPolicy, logger *logrus.Logger) *FailoverSender {
+	if policy.Threshold <= 0 {
+		policy.Threshold = 1
+	}
+	providers := make([]*provider, 0, len(senders))
+	for _, sender := range senders {
+		providers = append(providers, &provider{NamedSender: sender})
+	}
+	return &FailoverSender{
+		providers: providers,
+		policy:    policy,
+		logger:    logger,
+		now:       time.Now,
+	}
+}
+
+// Send delivers the email through the first available provider that accepts
+// it. The result names the provider that delivered it.
+func (f *FailoverSender) Send(ctx context.Context, email *repository.Email) (*repository.SendResult, error) {
+	var errs []error
+	for _, p := range f.providers {
+		if !p.allow(f.now(), f.policy.Cooldown) {
+			continue
+		}
+
+		result, err := p.Sender.Send(ctx, email)
+		if err == nil {
+			if p.success() {
+				f.logger.WithField("provider", p.Name).Info("Email provider recovered, circuit closed")
+			}
+			if result.Provider == "" {
+				result.Provider = p.Name
+			}
+			return result, nil
+		}
+
+		err = classifySendError(err)
+		if !IsTransient(err) {
+			// The provider answered; it is healthy even if the email is not.
+			p.success()
+			return nil, fmt.Errorf("%s: %w", p.Name, err)
+		}
+
+		log := f.logger.WithError(err).WithField("provider", p.Name)
+		if p.failure(f.now(), f.policy.Threshold) {
+			log.Warn("Email provider failing, circuit opened")
+		} else {
+			log.Warn("Email provider failed, trying the next one")
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", p.Name, err))
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil, transient(ErrNoEmailProvider)
+	}
+	return nil, transient(errors.Join(errs...))
+}
+
+// allow reports whether the provider may be tried now. An open circuit
+// admits a single probe once the cooldown has passed.
+func (p *provider) allow(now time.Time, cooldown time.Duration) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.openedAt.IsZero() {
+		return true
+	}
+	if p.probing || now.Sub(p.openedAt) < cooldown {
+		return false
+	}
+	p.probing = true
+	return true
+}
+
+// success closes the circuit and reports whether it had been open.
+func (p *provider) success() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	wasOpen := !p.openedAt.IsZero()
+	p.failures = 0
+	p.openedAt = time.Time{}
+	p.probing = false
+	return wasOpen
+}
+
+// failure counts a transient failure and reports whether it just opened
+// the circuit. A failed probe keeps it open for another cooldown.
+func (p *provider) failure(now time.Time, threshold int) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.probing {
+		p.probing = false
+		p.openedAt = now
+		return false
+	}
+	p.failures++
+	if p.openedAt.IsZero() && p.failures >= threshold {
+		p.openedAt = now
+		return true
+	}
+	return false
+}
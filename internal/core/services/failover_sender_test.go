@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"notification-service/internal/core/repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+var errUnreachable = errors.New("dial tcp: connection refused")
+
+// fakeSender answers every Send with err, counting the calls.
+type fakeSender struct {
+	err   error
+	calls int
+}
+
+func (s *fakeSender) Send(ctx context.Context, email *repository.Email) (*repository.SendResult, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &repository.SendResult{}, nil
+}
+
+// step is one email sent through the failover sender: the clock is moved
+// on by advance and the primary answers with primaryErr.
+type step struct {
+	advance     time.Duration
+	primaryErr  error
+	wantPrimary bool   // whether the primary was tried
+	wantVia     string // provider that delivered, "" for an error
+}
+
+func TestFailoverSender(t *testing.T) {
+	policy := BreakerPolicy{Threshold: 2, Cooldown: time.Minute}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "fails over on a transient error",
+			steps: []step{
+				{primaryErr: errUnreachable, wantPrimary: true, wantVia: "backup"},
+				{wantPrimary: true, wantVia: "primary"},
+			},
+		},
+		{
+			name: "fails over when credentials are refused",
+			steps: []step{
+				{primaryErr: repository.ErrProviderAuth, wantPrimary: true, wantVia: "backup"},
+			},
+		},
+		{
+			name: "does not fail over on a permanent error",
+			steps: []step{
+				{primaryErr: repository.ErrEmailRejected, wantPrimary: true, wantVia: ""},
+			},
+		},
+		{
+			name: "permanent errors do not count towards the threshold",
+			steps: []step{
+				{primaryErr: errUnreachable, wantPrimary: true, wantVia: "backup"},
+				{primaryErr: repository.ErrEmailRejected, wantPrimary: true, wantVia: ""},
+				{primaryErr: errUnreachable, wantPrimary: true, wantVia: "backup"},
+				{wantPrimary: true, wantVia: "primary"},
+			},
+		},
+		{
+			name: "opens the circuit at the threshold",
+			steps: []step{
+				{primaryErr: errUnreachable, wantPrimary: true, wantVia: "backup"},
+				{primaryErr: errUnreachable, wantPrimary: true, wantVia: "backup"},
+				{wantPrimary: false, wantVia: "backup"},
+				{advance: 30 * time.Second, wantPrimary: false, wantVia: "backup"},
+			},
+		},
+		{
+			name: "probes once after the cooldown and closes on success",
+			steps: []step{
+				{primaryErr: errUnreachable, wantPrimary: true, wantVia: "backup"},
+				{primaryErr: errUnreachable, wantPrimary: true, wantVia: "backup"},
+				{advance: time.Minute, wantPrimary: true, wantVia: "primary"},
+				{wantPrimary: true, wantVia: "primary"},
+			},
+		},
+		{
+			name: "a failed probe reopens the circuit for another cooldown",
+			steps: []step{
+				{primaryErr: errUnreachable, wantPrimary: true, wantVia: "backup"},
+				{primaryErr: errUnreachable, wantPrimary: true, wantVia: "backup"},
+				{advance: time.Minute, primaryErr: errUnreachable, wantPrimary: true, wantVia: "backup"},
+				{advance: 59 * time.Second, wantPrimary: false, wantVia: "backup"},
+				{advance: time.Second, wantPrimary: true, wantVia: "primary"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary, backup := &fakeSender{}, &fakeSender{}
+			f := newTestFailover(primary, backup, policy)
+			now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			f.now = func() time.Time { return now }
+
+			for i, s := range tt.steps {
+				now = now.Add(s.advance)
+				primary.err = s.primaryErr
+				before := primary.calls
+
+				result, err := f.Send(context.Background(), &repository.Email{})
+				if tried := primary.calls > before; tried != s.wantPrimary {
+					t.Errorf("step %d: primary tried = %v, want %v", i, tried, s.wantPrimary)
+				}
+				if s.wantVia == "" {
+					if err == nil || IsTransient(err) {
+						t.Errorf("step %d: err = %v, want a permanent error", i, err)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("step %d: Send: %v", i, err)
+				}
+				if result.Provider != s.wantVia {
+					t.Errorf("step %d: delivered via %q, want %q", i, result.Provider, s.wantVia)
+				}
+			}
+		})
+	}
+}
+
+func TestFailoverSenderAdmitsOneProbe(t *testing.T) {
+	primary, backup := &fakeSender{err: errUnreachable}, &fakeSender{}
+	f := newTestFailover(primary, backup, BreakerPolicy{Threshold: 1, Cooldown: time.Minute})
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	f.now = func() time.Time { return now }
+
+	if _, err := f.Send(context.Background(), &repository.Email{}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	now = now.Add(time.Minute)
+	p := f.providers[0]
+	if !p.allow(now, time.Minute) {
+		t.Fatal("first call after the cooldown was not admitted as a probe")
+	}
+	if p.allow(now, time.Minute) {
+		t.Error("a second probe was admitted while the first is in flight")
+	}
+}
+
+func TestFailoverSenderAllProvidersDown(t *testing.T) {
+	primary, backup := &fakeSender{err: errUnreachable}, &fakeSender{err: errUnreachable}
+	f := newTestFailover(primary, backup, BreakerPolicy{Threshold: 1, Cooldown: time.Minute})
+
+	_, err := f.Send(context.Background(), &repository.Email{})
+	if !IsTransient(err) || !errors.Is(err, errUnreachable) {
+		t.Errorf("err = %v, want a transient error wrapping both failures", err)
+	}
+	_, err = f.Send(context.Background(), &repository.Email{})
+	if !IsTransient(err) || !errors.Is(err, ErrNoEmailProvider) {
+		t.Errorf("err = %v, want ErrNoEmailProvider while every circuit is open", err)
+	}
+}
+
+func newTestFailover(primary, backup repository.EmailSender, policy BreakerPolicy) *FailoverSender {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return NewFailoverSender([]NamedSender{
+		{Name: "primary", Sender: primary},
+		{Name: "backup", Sender: backup},
+	}, policy, logger)
+}
@@ -0,0 +1,15 @@
+package services
+
+// Kafka headers that carry notification metadata alongside the JSON payload.
+const (
+	// HeaderNotificationID carries the ID returned to the caller of SendNotification.
+	HeaderNotificationID = "x-notification-id"
+	// HeaderIdempotencyKey carries the caller's key for suppressing duplicate sends.
+	HeaderIdempotencyKey = "x-idempotency-key"
+	// HeaderReplayOf carries the ID of the failed log a replayed event re-drives.
+	HeaderReplayOf = "x-replay-of"
+	// HeaderLocale carries the recipient's locale used to pick a template variant.
+	HeaderLocale = "x-locale"
+	// HeaderChannel overrides the route's channel, e.g. "sms".
+	HeaderChannel = "x-channel"
+)
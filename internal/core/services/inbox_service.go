@@ -0,0 +1,249 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"notification-service/internal/adapters/database/db"
+	"notification-service/internal/core/repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ProviderInbox is the provider name in-app deliveries are logged with.
+const ProviderInbox = "inbox"
+
+// inboxSubscriberBuffer is how many notifications a subscriber may fall
+// behind before it is dropped.
+const inboxSubscriberBuffer = 16
+
+// ErrInboxClosed is returned by Subscribe once the service is shutting down.
+var ErrInboxClosed = errors.New("inbox subscriptions are closed")
+
+// InboxItem is a rendered in-app notification for one user's inbox.
+type InboxItem struct {
+	UserID         string
+	NotificationID string
+	TemplateName   string
+	Title          string
+	Body           string
+}
+
+// InboxPage is one page of a user's inbox, newest first.
+type InboxPage struct {
+	Notifications []db.InboxNotification
+	NextPageToken string
+	// UnreadCount covers the whole inbox, not just this page.
+	UnreadCount int64
+}
+
+// InboxService keeps the per-user inboxes of the in-app channel and pushes
+// new notifications to subscribed clients.
+//
+// Deliver only stores a notification. The database announces every new row
+// to all service instances, which call Publish, so a client is pushed its
+// notifications whichever instance it is connected to. Subscribers that fall
+// behind, or that may have missed notifications while the announcements were
+// interrupted, are dropped; their clients reconnect and catch up with List.
+type InboxService struct {
+	repo   repository.InboxRepository
+	logger *logrus.Logger
+
+	mu          sync.Mutex
+	subscribers map[string]map[*inboxSubscriber]struct{}
+	closed      bool
+}
+
+type inboxSubscriber struct {
+	updates chan db.InboxNotification
+}
+
+func NewInboxService(repo repository.InboxRepository, logger *logrus.Logger) *InboxService {
+	return &InboxService{
+		repo:        repo,
+		logger:      logger,
+		subscribers: make(map[string]map[*inboxSubscriber]struct{}),
+	}
+}
+
+// Deliver stores item in its user's inbox. Delivering a notification the
+// inbox already holds, as for a redelivered event, changes nothing.
+func (s *InboxService) Deliver(ctx context.Context, item InboxItem) (*repository.SendResult, error) {
+	entry, created, err := s.repo.Create(ctx, db.CreateInboxNotificationParams{
+		UserID:         item.UserID,
+		NotificationID: item.NotificationID,
+		TemplateName:   item.TemplateName,
+		Title:          item.Title,
+		Body:           item.Body,
+		CreatedAt:      time.Now(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store inbox notification: %w", err)
+	}
+	result := &repository.SendResult{Provider: ProviderInbox}
+	if created {
+		result.MessageID = strconv.FormatInt(entry.ID, 10)
+	} else {
+		s.logger.WithFields(logrus.Fields{
+			"notification_id": item.NotificationID,
+			"user_id":         item.UserID,
+		}).Info("Notification already in inbox")
+	}
+	return result, nil
+}
+
+// List returns a page of the user's inbox. The page token is the ID of the
+// last notification on the previous page, as for QueryService.List.
+func (s *InboxService) List(ctx context.Context, userID string, unreadOnly bool, pageSize int, pageToken string) (InboxPage, error) {
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	params := db.ListInboxNotificationsParams{
+		UserID:     userID,
+		UnreadOnly: unreadOnly,
+		// Fetch one extra row to know whether another page exists.
+		RowLimit: int32(pageSize + 1),
+	}
+	if pageToken != "" {
+		beforeID, err := strconv.ParseInt(pageToken, 10, 64)
+		if err != nil || beforeID <= 0 {
+			return InboxPage{}, ErrInvalidPageToken
+		}
+		params.BeforeID = sql.NullInt64{Int64: beforeID, Valid: true}
+	}
+
+	notifications, err := s.repo.List(ctx, params)
+	if err != nil {
+		return InboxPage{}, fmt.Errorf("failed to list inbox of %s: %w", userID, err)
+	}
+	unread, err := s.repo.CountUnread(ctx, userID)
+	if err != nil {
+		return InboxPage{}, fmt.Errorf("failed to count unread notifications of %s: %w", userID, err)
+	}
+
+	page := InboxPage{Notifications: notifications, UnreadCount: unread}
+	if len(notifications) > pageSize {
+		page.Notifications = notifications[:pageSize]
+		page.NextPageToken = strconv.FormatInt(page.Notifications[pageSize-1].ID, 10)
+	}
+	return page, nil
+}
+
+// MarkRead marks the given notifications of the user as read. IDs that are
+// already read or belong to another user are skipped.
+func (s *InboxService) MarkRead(ctx context.Context, userID string, ids []int64) (int64, error) {
+	updated, err := s.repo.MarkRead(ctx, userID, ids, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark inbox notifications of %s read: %w", userID, err)
+	}
+	return updated, nil
+}
+
+// MarkAllRead marks every unread notification of the user as read.
+func (s *InboxService) MarkAllRead(ctx context.Context, userID string) (int64, error) {
+	updated, err := s.repo.MarkAllRead(ctx, userID, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark inbox of %s read: %w", userID, err)
+	}
+	return updated, nil
+}
+
+// Subscribe returns a channel receiving the user's new notifications and a
+// function ending the subscription. The channel is closed when the
+// subscription is dropped.
+func (s *InboxService) Subscribe(userID string) (<-chan db.InboxNotification, func(), error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil, nil, ErrInboxClosed
+	}
+
+	sub := &inboxSubscriber{updates: make(chan db.InboxNotification, inboxSubscriberBuffer)}
+	if s.subscribers[userID] == nil {
+		s.subscribers[userID] = make(map[*inboxSubscriber]struct{})
+	}
+	s.subscribers[userID][sub] = struct{}{}
+
+	unsubscribe := func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.drop(userID, sub)
+	}
+	return sub.updates, unsubscribe, nil
+}
+
+// Publish pushes the inbox notification with the given ID to its user's
+// subscribers on this instance.
+func (s *InboxService) Publish(ctx context.Context, id int64) {
+	s.mu.Lock()
+	idle := len(s.subscribers) == 0
+	s.mu.Unlock()
+	if idle {
+		return
+	}
+
+	entry, err := s.repo.Get(ctx, id)
+	if err != nil {
+		s.logger.WithError(err).WithField("inbox_id", id).Error("Failed to load inbox notification for subscribers")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for sub := range s.subscribers[entry.UserID] {
+		select {
+		case sub.updates <- entry:
+		default:
+			s.logger.WithField("user_id", entry.UserID).Warn("Dropping inbox subscriber that fell behind")
+			s.drop(entry.UserID, sub)
+		}
+	}
+}
+
+// Resync drops every subscriber, after notifications may have been missed,
+// so that clients reconnect and list their inbox again.
+func (s *InboxService) Resync() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dropAll()
+}
+
+// Close ends every subscription and refuses new ones, so streaming calls
+// return before the gRPC server stops.
+func (s *InboxService) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	s.dropAll()
+}
+
+// drop removes a subscriber and closes its channel, once. s.mu must be held.
+func (s *InboxService) drop(userID string, sub *inboxSubscriber) {
+	subs := s.subscribers[userID]
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(s.subscribers, userID)
+	}
+	close(sub.updates)
+}
+
+func (s *InboxService) dropAll() {
+	for userID, subs := range s.subscribers {
+		for sub := range subs {
+			close(sub.updates)
+		}
+		delete(s.subscribers, userID)
+	}
+}
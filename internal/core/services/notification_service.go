@@ -4,84 +4,523 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"time"
 
 	"notification-service/internal/adapters/database/db"
 	"notification-service/internal/adapters/mailme"
 	"notification-service/internal/core/repository"
 
+	"github.com/hashicorp/go-uuid"
 	"github.com/sirupsen/logrus"
 )
 
 type SendRequest struct {
-	To           string
-	TemplateName string
-	Data         map[string]interface{}
+	// NotificationID correlates every attempt of the same notification.
+	NotificationID string
+	To             string
+	TemplateName   string
+	Data           map[string]interface{}
+	// ReplayOf is the ID of the failed log this request re-drives, or 0.
+	ReplayOf int32
+	// IdempotencyKey suppresses duplicates of the same notification, if set.
+	IdempotencyKey string
+	// Locale selects a template variant, e.g. "de-DE"; empty means default.
+	Locale string
+	// Channel is one of the repository.Channel* constants; empty means
+	// email. To is then a phone number, webhook endpoint name or, for
+	// in-app notifications, a user ID instead.
+	Channel string
+	// Topic is the Kafka topic the request was consumed from, recorded so
+	// a replay is published back to it.
+	Topic string
+}
+
+// idempotencyLease is how long a claimed key stays pending. A process that
+// dies mid-send holds its keys no longer than this, after which the
+// redelivered event can claim them and send.
+const idempotencyLease = time.Minute
+
+// NewNotificationID returns a random UUID identifying a notification across
+// the gRPC response, Kafka headers, log lines and notification_logs.
+func NewNotificationID() string {
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		// Only fails if crypto/rand does; there is nothing sensible to fall back to.
+		panic(fmt.Sprintf("failed to generate notification ID: %v", err))
+	}
+	return id
 }
 
 type NotificationService struct {
-	templateRepo repository.TemplateRepository
-	logRepo      repository.NotificationLogRepository
-	mailer       *mailme.Mailer
-	logger       *logrus.Logger
+	templateRepo   repository.TemplateRepository
+	logRepo        repository.NotificationLogRepository
+	idempotency    repository.IdempotencyRepository
+	idempotencyTTL time.Duration
+	attachments    *AttachmentResolver
+	renderer       *mailme.Renderer
+	sender         repository.EmailSender
+	channels       Channels
+	logger         *logrus.Logger
 }
 
 func NewNotificationService(
 	templateRepo repository.TemplateRepository,
 	logRepo repository.NotificationLogRepository,
-	mailer *mailme.Mailer,
+	idempotency repository.IdempotencyRepository,
+	idempotencyTTL time.Duration,
+	attachments *AttachmentResolver,
+	renderer *mailme.Renderer,
+	sender repository.EmailSender,
+	channels Channels,
 	logger *logrus.Logger,
 ) *NotificationService {
 	return &NotificationService{
-		templateRepo: templateRepo,
-		logRepo:      logRepo,
-		mailer:       mailer,
-		logger:       logger,
+		templateRepo:   templateRepo,
+		logRepo:        logRepo,
+		idempotency:    idempotency,
+		idempotencyTTL: idempotencyTTL,
+		attachments:    attachments,
+		renderer:       renderer,
+		sender:         sender,
+		channels:       channels,
+		logger:         logger,
 	}
 }
 
-func (s *NotificationService) SendNotification(ctx context.Context, req SendRequest) {
+// SendNotification renders and sends a single notification. Failures are
+// logged and returned as a *DeliveryError so the caller can decide whether
+// to retry: permanent failures are logged as "failed", transient ones as
+// "retrying" until the caller gives up and calls LogExhausted.
+//
+// Requests carrying an idempotency key that was already delivered are
+// logged as "duplicate" and skipped without error. While another attempt
+// holds the key's lease the request fails transiently, to be retried.
+func (s *NotificationService) SendNotification(ctx context.Context, req SendRequest) (err error) {
+	req.Channel = channelOrDefault(req.Channel)
 	log := s.logger.WithFields(logrus.Fields{
-		"recipient": req.To,
-		"template":  req.TemplateName,
+		"notification_id": req.NotificationID,
+		"recipient":       req.To,
+		"template":        req.TemplateName,
+		"channel":         req.Channel,
 	})
 
-	template, err := s.templateRepo.GetTemplate(ctx, req.TemplateName)
+	if req.IdempotencyKey != "" {
+		log = log.WithField("idempotency_key", req.IdempotencyKey)
+		key := idempotencyKeyFor(req)
+		claim, claimErr := s.idempotency.Claim(ctx, key, req.NotificationID, idempotencyLease)
+		if claimErr != nil {
+			log.WithError(claimErr).Error("Failed to check idempotency key")
+			return transient(fmt.Errorf("failed to check idempotency key: %w", claimErr))
+		}
+		switch claim {
+		case repository.ClaimDone:
+			log.Info("Duplicate notification skipped")
+			s.logAttempt(ctx, req, "duplicate", "idempotency key already processed")
+			return nil
+		case repository.ClaimPending:
+			log.Warn("Another attempt of this notification is in progress")
+			return transient(errors.New("idempotency key is held by another attempt"))
+		}
+		// Mark the key done once the send is logged, or give it back on
+		// failure so a retry or replay can claim it again. Both must happen
+		// even when ctx was cancelled by a shutdown.
+		defer func() {
+			bg := context.WithoutCancel(ctx)
+			if err != nil {
+				if releaseErr := s.idempotency.Release(bg, key, req.NotificationID); releaseErr != nil {
+					log.WithError(releaseErr).Error("Failed to release idempotency key")
+				}
+				return
+			}
+			if completeErr := s.idempotency.Complete(bg, key, req.NotificationID, s.idempotencyTTL); completeErr != nil {
+				log.WithError(completeErr).Error("Failed to mark idempotency key done")
+			}
+		}()
+	}
+
+	template, err := s.templateRepo.GetTemplate(ctx, req.TemplateName, req.Locale)
 	if err != nil {
 		log.WithError(err).Error("Failed to get template")
 		s.logAttempt(ctx, req, "failed", "template not found")
-		return
+		return permanent(fmt.Errorf("template %q not found: %w", req.TemplateName, err))
 	}
+	// From here on, log the locale variant actually used rather than the one requested.
+	if template.Locale != req.Locale {
+		log = log.WithFields(logrus.Fields{"requested_locale": req.Locale, "locale": template.Locale})
+	}
+	req.Locale = template.Locale
 
-	// The mailer's Mail function handles subject/body parsing
-	err = s.mailer.Mail(req.To, template.Subject, template.BodyHTML, template.BodyText, req.Data)
+	switch req.Channel {
+	case repository.ChannelSMS:
+		return s.sendSMS(ctx, req, template, log)
+	case repository.ChannelWebhook:
+		return s.sendWebhook(ctx, req, template, log)
+	case repository.ChannelChat:
+		return s.sendChat(ctx, req, template, log)
+	case repository.ChannelInApp:
+		return s.sendInApp(ctx, req, template, log)
+	}
+	if template.BodyHTML == "" {
+		err := fmt.Errorf("template %q has no email body", req.TemplateName)
+		log.WithError(err).Error("Template cannot be sent by email")
+		s.logAttempt(ctx, req, "failed", err.Error())
+		return permanent(err)
+	}
 
+	// Extra recipients and attachments ride along in the payload, so they
+	// are logged and replayed with it, but they are not template data.
+	// Inline attachment content is left out of the log, which makes such
+	// notifications unreplayable; blob attachments are read again.
+	recipients, templateData, err := ExtractRecipients(req.To, req.Data)
 	if err != nil {
-		log.WithError(err).Error("Failed to send notification")
+		log.WithError(err).Error("Notification recipients are invalid")
 		s.logAttempt(ctx, req, "failed", err.Error())
-		return
+		return permanent(err)
+	}
+	attachments, templateData, err := ExtractAttachments(templateData)
+	var files []repository.EmailAttachment
+	if err == nil {
+		files, err = s.attachments.Load(ctx, attachments)
+	}
+	if errors.Is(err, ErrInvalidAttachment) {
+		log.WithError(err).Error("Notification attachments are invalid")
+		s.logAttempt(ctx, req, "failed", err.Error())
+		return permanent(err)
+	}
+	if err != nil {
+		log.WithError(err).Warn("Transient failure loading notification attachments")
+		s.logAttempt(ctx, req, "retrying", err.Error())
+		return transient(err)
+	}
+
+	data, err := template.PrepareData(templateData)
+	if err != nil {
+		log.WithError(err).Error("Notification data does not satisfy template variables")
+		s.logAttempt(ctx, req, "failed", err.Error())
+		return permanent(err)
+	}
+
+	rendered, err := s.renderer.Render(emailContent(template), data)
+	if err != nil {
+		log.WithError(err).Error("Failed to render notification")
+		s.logAttempt(ctx, req, "failed", err.Error())
+		return permanent(err)
+	}
+	if rendered.TextErr != nil {
+		// HTML is the primary part, so a broken text template is not fatal.
+		log.WithError(rendered.TextErr).Warn("Failed to render plain text template, using text generated from HTML")
+	}
+
+	result, err := s.sender.Send(ctx, &repository.Email{
+		From:        template.Meta.From,
+		To:          recipients.To,
+		Cc:          recipients.Cc,
+		Bcc:         recipients.Bcc,
+		ReplyTo:     recipients.ReplyTo,
+		Subject:     rendered.Subject,
+		HTML:        rendered.HTML,
+		Text:        rendered.Text,
+		Attachments: files,
+	})
+	if err != nil {
+		return s.sendFailed(ctx, req, err, log)
 	}
 
-	log.Info("Notification sent successfully")
-	s.logAttempt(ctx, req, "sent", "Successfully sent")
+	log.WithFields(logrus.Fields{"provider": result.Provider, "message_id": result.MessageID}).Info("Notification sent successfully")
+	s.logSent(ctx, req, result)
+	return nil
+}
+
+// sendSMS renders the template's SMS body and hands it to the SMS provider.
+func (s *NotificationService) sendSMS(ctx context.Context, req SendRequest, template *repository.Template, log *logrus.Entry) error {
+	var err error
+	sms := s.channels.SMS
+	switch {
+	case sms == nil:
+		err = errors.New("sms channel is not configured")
+	case template.BodySMS == "":
+		err = fmt.Errorf("template %q has no SMS body", req.TemplateName)
+	default:
+		err = ValidatePhoneNumber(req.To)
+	}
+	if err != nil {
+		log.WithError(err).Error("Notification cannot be sent by SMS")
+		s.logAttempt(ctx, req, "failed", err.Error())
+		return permanent(err)
+	}
+
+	data, err := template.PrepareData(req.Data)
+	if err != nil {
+		log.WithError(err).Error("Notification data does not satisfy template variables")
+		s.logAttempt(ctx, req, "failed", err.Error())
+		return permanent(err)
+	}
+	body, err := renderText("sms", template.BodySMS, data)
+	if err != nil {
+		log.WithError(err).Error("Failed to render notification")
+		s.logAttempt(ctx, req, "failed", err.Error())
+		return permanent(err)
+	}
+	if n, limit := SMSSegments(body), sms.maxSegments(); n > limit {
+		err := fmt.Errorf("sms is %d segments long, the limit is %d", n, limit)
+		log.WithError(err).Error("Rendered SMS is too long")
+		s.logAttempt(ctx, req, "failed", err.Error())
+		return permanent(err)
+	}
+
+	result, err := sms.Sender.Send(ctx, &repository.SMS{To: req.To, Body: body})
+	if err != nil {
+		return s.sendFailed(ctx, req, err, log)
+	}
+
+	log.WithFields(logrus.Fields{"provider": result.Provider, "message_id": result.MessageID}).Info("Notification sent successfully")
+	s.logSent(ctx, req, result)
+	return nil
+}
+
+// sendWebhook renders the template's JSON payload and posts it to the
+// endpoint named by req.To. Failed calls are retried by the caller like
+// any other transient failure, which gives 5xx answers backoff for free.
+func (s *NotificationService) sendWebhook(ctx context.Context, req SendRequest, template *repository.Template, log *logrus.Entry) error {
+	var err error
+	switch {
+	case s.channels.Webhook == nil:
+		err = errors.New("webhook channel is not configured")
+	case template.BodyWebhook == "":
+		err = fmt.Errorf("template %q has no webhook body", req.TemplateName)
+	}
+	return s.sendPayload(ctx, req, template, template.BodyWebhook, err, log, func(payload []byte) (*repository.SendResult, error) {
+		return s.channels.Webhook.Send(ctx, &repository.Webhook{
+			Endpoint:       req.To,
+			NotificationID: req.NotificationID,
+			Payload:        payload,
+		})
+	})
+}
+
+// sendChat renders the template's chat message and posts it to the
+// incoming webhook named by req.To.
+func (s *NotificationService) sendChat(ctx context.Context, req SendRequest, template *repository.Template, log *logrus.Entry) error {
+	var err error
+	switch {
+	case s.channels.Chat == nil:
+		err = errors.New("chat channel is not configured")
+	case template.BodyChat == "":
+		err = fmt.Errorf("template %q has no chat body", req.TemplateName)
+	}
+	return s.sendPayload(ctx, req, template, template.BodyChat, err, log, func(payload []byte) (*repository.SendResult, error) {
+		return s.channels.Chat.Send(ctx, &repository.ChatMessage{Webhook: req.To, Payload: payload})
+	})
+}
+
+// sendInApp renders the template's subject and in-app body and stores them
+// in the inbox of the user named by req.To.
+func (s *NotificationService) sendInApp(ctx context.Context, req SendRequest, template *repository.Template, log *logrus.Entry) error {
+	var err error
+	switch {
+	case s.channels.InApp == nil:
+		err = errors.New("inapp channel is not configured")
+	case template.BodyInApp == "":
+		err = fmt.Errorf("template %q has no in-app body", req.TemplateName)
+	}
+	if err != nil {
+		log.WithError(err).Error("Notification cannot be sent in-app")
+		s.logAttempt(ctx, req, "failed", err.Error())
+		return permanent(err)
+	}
+
+	data, err := template.PrepareData(req.Data)
+	if err != nil {
+		log.WithError(err).Error("Notification data does not satisfy template variables")
+		s.logAttempt(ctx, req, "failed", err.Error())
+		return permanent(err)
+	}
+	title, err := renderText("subject", template.Subject, data)
+	var body string
+	if err == nil {
+		data["subject"] = title
+		body, err = renderText("inapp", template.BodyInApp, data)
+	}
+	if err != nil {
+		log.WithError(err).Error("Failed to render notification")
+		s.logAttempt(ctx, req, "failed", err.Error())
+		return permanent(err)
+	}
+
+	result, err := s.channels.InApp.Deliver(ctx, InboxItem{
+		UserID:         req.To,
+		NotificationID: req.NotificationID,
+		TemplateName:   req.TemplateName,
+		Title:          title,
+		Body:           body,
+	})
+	if err != nil {
+		return s.sendFailed(ctx, req, err, log)
+	}
+
+	log.WithFields(logrus.Fields{"provider": result.Provider, "message_id": result.MessageID}).Info("Notification sent successfully")
+	s.logSent(ctx, req, result)
+	return nil
+}
+
+// sendPayload renders a JSON body and hands it to send, for the channels
+// that deliver JSON documents. unavailable, if set, is why the channel
+// cannot take the notification.
+func (s *NotificationService) sendPayload(ctx context.Context, req SendRequest, template *repository.Template, source string, unavailable error, log *logrus.Entry, send func(payload []byte) (*repository.SendResult, error)) error {
+	if unavailable != nil {
+		log.WithError(unavailable).Error("Notification cannot be sent over its channel")
+		s.logAttempt(ctx, req, "failed", unavailable.Error())
+		return permanent(unavailable)
+	}
+
+	data, err := template.PrepareData(req.Data)
+	if err != nil {
+		log.WithError(err).Error("Notification data does not satisfy template variables")
+		s.logAttempt(ctx, req, "failed", err.Error())
+		return permanent(err)
+	}
+	payload, err := renderJSON(req.Channel, source, data)
+	if err != nil {
+		log.WithError(err).Error("Failed to render notification")
+		s.logAttempt(ctx, req, "failed", err.Error())
+		return permanent(err)
+	}
+
+	result, err := send(payload)
+	if err != nil {
+		return s.sendFailed(ctx, req, err, log)
+	}
+
+	log.WithFields(logrus.Fields{"provider": result.Provider, "response_code": result.ResponseCode}).Info("Notification sent successfully")
+	s.logSent(ctx, req, result)
+	return nil
+}
+
+// sendFailed logs a provider failure as "retrying" or "failed", depending
+// on whether it is worth another attempt, and returns it classified.
+func (s *NotificationService) sendFailed(ctx context.Context, req SendRequest, err error, log *logrus.Entry) error {
+	err = classifySendError(err)
+	status := "failed"
+	if IsTransient(err) {
+		log.WithError(err).Warn("Transient failure sending notification")
+		status = "retrying"
+	} else {
+		log.WithError(err).Error("Failed to send notification")
+	}
+
+	params := s.logParams(req, status, err.Error())
+	var statusErr *repository.StatusError
+	if errors.As(err, &statusErr) {
+		params.ResponseCode = sql.NullInt32{Int32: int32(statusErr.StatusCode), Valid: true}
+	}
+	s.writeLog(ctx, params)
+	return err
+}
+
+// emailContent hands a template's sources, layout and partials to the renderer.
+func emailContent(template *repository.Template) *mailme.Content {
+	return &mailme.Content{
+		Subject:   template.Subject,
+		HTML:      template.BodyHTML,
+		Text:      template.BodyText,
+		Layout:    template.Layout,
+		Partials:  template.Partials,
+		InlineCSS: template.Meta.InlineCSS,
+	}
+}
+
+// LogExhausted records that a transiently failing notification ran out of
+// retries and was handed to the dead-letter topic.
+func (s *NotificationService) LogExhausted(ctx context.Context, req SendRequest, attempts int, cause error) {
+	s.logAttempt(ctx, req, "failed", fmt.Sprintf("retries exhausted after %d attempts: %v", attempts, cause))
+}
+
+// PurgeExpiredKeys deletes idempotency keys whose TTL has passed.
+func (s *NotificationService) PurgeExpiredKeys(ctx context.Context) {
+	deleted, err := s.idempotency.DeleteExpired(ctx)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to purge expired idempotency keys")
+		return
+	}
+	if deleted > 0 {
+		s.logger.WithField("count", deleted).Info("Purged expired idempotency keys")
+	}
 }
 
 func (s *NotificationService) logAttempt(ctx context.Context, req SendRequest, status, details string) {
-	dataJSON, err := json.Marshal(req.Data)
+	s.writeLog(ctx, s.logParams(req, status, details))
+}
+
+// logSent records a delivery together with the provider that made it.
+func (s *NotificationService) logSent(ctx context.Context, req SendRequest, result *repository.SendResult) {
+	params := s.logParams(req, "sent", "Successfully sent")
+	params.Provider = sql.NullString{String: result.Provider, Valid: result.Provider != ""}
+	params.ProviderMessageID = sql.NullString{String: result.MessageID, Valid: result.MessageID != ""}
+	params.ResponseCode = sql.NullInt32{Int32: int32(result.ResponseCode), Valid: result.ResponseCode != 0}
+	s.writeLog(ctx, params)
+}
+
+func (s *NotificationService) logParams(req SendRequest, status, details string) db.CreateNotificationLogParams {
+	dataJSON, err := json.Marshal(logData(req.Data))
 	if err != nil {
 		s.logger.WithError(err).Error("Failed to marshal notification data for logging")
 		dataJSON = []byte("{}") // Log empty JSON on error
 	}
 
-	params := db.CreateNotificationLogParams{
+	// Recorded even when some addresses are malformed, so the log shows them.
+	recipients, _, _ := ExtractRecipients(req.To, req.Data)
+	recipientsJSON, err := json.Marshal(recipients)
+	if err != nil {
+		recipientsJSON = []byte("{}")
+	}
+
+	return db.CreateNotificationLogParams{
 		Recipient:    req.To,
 		TemplateName: req.TemplateName,
 		Status:       status,
 		Details:      sql.NullString{String: details, Valid: true},
 		Data:         dataJSON,
+		Recipients:   recipientsJSON,
 		AttemptedAt:  time.Now(),
+		ReplayOf:     sql.NullInt32{Int32: req.ReplayOf, Valid: req.ReplayOf != 0},
+		NotificationID: sql.NullString{
+			String: req.NotificationID,
+			Valid:  req.NotificationID != "",
+		},
+		IdempotencyKey: sql.NullString{
+			String: req.IdempotencyKey,
+			Valid:  req.IdempotencyKey != "",
+		},
+		Locale: sql.NullString{
+			String: req.Locale,
+			Valid:  req.Locale != "",
+		},
+		Channel: channelOrDefault(req.Channel),
+		Topic:   sql.NullString{String: req.Topic, Valid: req.Topic != ""},
 	}
+}
+
+// idempotencyKeyFor scopes the caller's key to the channel, so an event
+// routed to several channels is delivered once on each. Email keeps the
+// bare key, as stored before there were other channels.
+func idempotencyKeyFor(req SendRequest) string {
+	if req.Channel == repository.ChannelEmail {
+		return req.IdempotencyKey
+	}
+	return req.IdempotencyKey + "#" + req.Channel
+}
+
+// channelOrDefault treats requests without a channel as email.
+func channelOrDefault(channel string) string {
+	if channel == "" {
+		return repository.ChannelEmail
+	}
+	return channel
+}
 
+func (s *NotificationService) writeLog(ctx context.Context, params db.CreateNotificationLogParams) {
 	if err := s.logRepo.CreateLog(ctx, params); err != nil {
 		s.logger.WithError(err).Error("Failed to write notification log to database")
 	}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"notification-service/internal/adapters/mailme"
+	"notification-service/internal/core/repository"
+)
+
+// Preview is a rendered template plus anything that looked wrong while rendering it.
+type Preview struct {
+	// Locale is the variant that was rendered after locale fallback.
+	Locale   string
+	Subject  string
+	BodyHTML string
+	BodyText string
+	Warnings []string
+}
+
+// PreviewService renders templates without sending or logging anything.
+type PreviewService struct {
+	templateRepo repository.TemplateRepository
+	renderer     *mailme.Renderer
+}
+
+func NewPreviewService(templateRepo repository.TemplateRepository, renderer *mailme.Renderer) *PreviewService {
+	return &PreviewService{
+		templateRepo: templateRepo,
+		renderer:     renderer,
+	}
+}
+
+// Render runs the same defaults merge and rendering path as a real send.
+// Missing required variables and placeholders without a value are reported
+// as warnings rather than errors, so a partial preview is still returned.
+func (s *PreviewService) Render(ctx context.Context, templateName, locale, recipient string, data map[string]interface{}) (*Preview, error) {
+	template, err := s.templateRepo.GetTemplate(ctx, templateName, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	if recipient != "" {
+		data["email"] = recipient
+	}
+
+	var warnings []string
+	missing := make(map[string]bool)
+	merged, err := template.PrepareData(data)
+	var missingErr *repository.MissingVariablesError
+	if errors.As(err, &missingErr) {
+		for _, name := range missingErr.Missing {
+			missing[name] = true
+			warnings = append(warnings, fmt.Sprintf("required variable %q is missing", name))
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	// Placeholders that are used but neither required nor supplied render as "<no value>".
+	parts := []struct{ name, src string }{
+		{"subject", template.Subject},
+		{"body_html", template.BodyHTML},
+		{"body_text", template.BodyText},
+		{"layout", template.Layout},
+	}
+	for _, name := range sortedKeys(template.Partials) {
+		parts = append(parts, struct{ name, src string }{"partial " + name, template.Partials[name]})
+	}
+	for _, part := range parts {
+		vars, err := templateVariables(part.name, part.src)
+		if err != nil {
+			continue // the render below reports parse errors
+		}
+		for _, name := range sortedKeys(vars) {
+			if _, ok := merged[name]; ok || missing[name] || name == "subject" {
+				continue
+			}
+			missing[name] = true
+			warnings = append(warnings, fmt.Sprintf("placeholder %q in %s has no value", name, part.name))
+		}
+	}
+
+	rendered, err := s.renderer.Render(emailContent(template), merged)
+	if err != nil {
+		return nil, err
+	}
+	if rendered.TextErr != nil {
+		warnings = append(warnings, fmt.Sprintf("plain text body failed to render and would be generated from the HTML instead: %v", rendered.TextErr))
+	}
+
+	return &Preview{
+		Locale:   template.Locale,
+		Subject:  rendered.Subject,
+		BodyHTML: rendered.HTML,
+		BodyText: rendered.Text,
+		Warnings: warnings,
+	}, nil
+}
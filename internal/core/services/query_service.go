@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"notification-service/internal/adapters/database/db"
+	"notification-service/internal/core/repository"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+var (
+	// ErrNotificationNotFound is returned when no log matches a status lookup.
+	ErrNotificationNotFound = errors.New("notification not found")
+	// ErrInvalidPageToken is returned when a list cursor cannot be decoded.
+	ErrInvalidPageToken = errors.New("invalid page token")
+)
+
+// ListFilter selects notification logs for ListNotifications. All fields are optional.
+type ListFilter struct {
+	NotificationID string
+	Recipient      string
+	TemplateName   string
+	Status         string
+	After          time.Time
+	Before         time.Time
+	PageSize       int
+	PageToken      string
+}
+
+// ListPage is one page of notification logs, newest first.
+type ListPage struct {
+	Logs          []db.NotificationLog
+	NextPageToken string
+}
+
+// QueryService answers read-only questions about past notification attempts.
+type QueryService struct {
+	logRepo repository.NotificationLogRepository
+}
+
+func NewQueryService(logRepo repository.NotificationLogRepository) *QueryService {
+	return &QueryService{logRepo: logRepo}
+}
+
+// GetStatus returns a single notification log by ID.
+func (s *QueryService) GetStatus(ctx context.Context, id int32) (db.NotificationLog, error) {
+	entry, err := s.logRepo.GetLog(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return db.NotificationLog{}, ErrNotificationNotFound
+	}
+	if err != nil {
+		return db.NotificationLog{}, fmt.Errorf("failed to load notification log %d: %w", id, err)
+	}
+	return entry, nil
+}
+
+// GetLatestByNotificationID returns the most recent attempt for a notification ID.
+func (s *QueryService) GetLatestByNotificationID(ctx context.Context, notificationID string) (db.NotificationLog, error) {
+	entry, err := s.logRepo.GetLatestLogByNotificationID(ctx, notificationID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return db.NotificationLog{}, ErrNotificationNotFound
+	}
+	if err != nil {
+		return db.NotificationLog{}, fmt.Errorf("failed to load notification %s: %w", notificationID, err)
+	}
+	return entry, nil
+}
+
+// List returns a page of logs matching the filter. The page token is the ID
+// of the last log on the previous page; pages are keyed by ID so rows written
+// while paging do not shift later pages.
+func (s *QueryService) List(ctx context.Context, filter ListFilter) (ListPage, error) {
+	pageSize := filter.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	params := db.ListNotificationLogsParams{
+		NotificationID:  nullString(filter.NotificationID),
+		Recipient:       nullString(filter.Recipient),
+		TemplateName:    nullString(filter.TemplateName),
+		Status:          nullString(filter.Status),
+		AttemptedAfter:  nullTime(filter.After),
+		AttemptedBefore: nullTime(filter.Before),
+		// Fetch one extra row to know whether another page exists.
+		RowLimit: int32(pageSize + 1),
+	}
+	if filter.PageToken != "" {
+		beforeID, err := strconv.ParseInt(filter.PageToken, 10, 32)
+		if err != nil || beforeID <= 0 {
+			return ListPage{}, ErrInvalidPageToken
+		}
+		params.BeforeID = sql.NullInt32{Int32: int32(beforeID), Valid: true}
+	}
+
+	logs, err := s.logRepo.ListLogs(ctx, params)
+	if err != nil {
+		return ListPage{}, fmt.Errorf("failed to list notification logs: %w", err)
+	}
+
+	page := ListPage{Logs: logs}
+	if len(logs) > pageSize {
+		page.Logs = logs[:pageSize]
+		page.NextPageToken = strconv.Itoa(int(page.Logs[pageSize-1].ID))
+	}
+	return page, nil
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+)
+
+// Kafka payload keys for addresses beyond the primary "email" recipient.
+// Like attachments, they are stripped from the data before templates see it.
+const (
+	PayloadAdditionalTo = "additional_to"
+	PayloadCc           = "cc"
+	PayloadBcc          = "bcc"
+	PayloadReplyTo      = "reply_to"
+)
+
+// ErrInvalidRecipient marks malformed addresses in a notification.
+var ErrInvalidRecipient = errors.New("invalid recipient")
+
+// Recipients lists every address a notification is sent to. To always
+// starts with the primary recipient.
+type Recipients struct {
+	To      []string `json:"to"`
+	Cc      []string `json:"cc,omitempty"`
+	Bcc     []string `json:"bcc,omitempty"`
+	ReplyTo []string `json:"reply_to,omitempty"`
+}
+
+// ExtractRecipients removes the extra address lists from a Kafka payload and
+// returns them, with primary first in To, together with the remaining data.
+// data itself is not modified.
+func ExtractRecipients(primary string, data map[string]interface{}) (Recipients, map[string]interface{}, error) {
+	recipients := Recipients{To: []string{primary}}
+	rest := make(map[string]interface{}, len(data))
+	var errs []error
+	for key, value := range data {
+		var list *[]string
+		switch key {
+		case PayloadAdditionalTo:
+			list = &recipients.To
+		case PayloadCc:
+			list = &recipients.Cc
+		case PayloadBcc:
+			list = &recipients.Bcc
+		case PayloadReplyTo:
+			list = &recipients.ReplyTo
+		default:
+			rest[key] = value
+			continue
+		}
+		addresses, err := addressList(key, value)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		*list = append(*list, addresses...)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return recipients, rest, err
+	}
+	return recipients, rest, recipients.Validate()
+}
+
+// Validate checks that every address parses.
+func (r Recipients) Validate() error {
+	var errs []error
+	for _, group := range []struct {
+		field     string
+		addresses []string
+	}{
+		{"to", r.To}, {PayloadCc, r.Cc}, {PayloadBcc, r.Bcc}, {PayloadReplyTo, r.ReplyTo},
+	} {
+		for _, address := range group.addresses {
+			if _, err := mail.ParseAddress(address); err != nil {
+				errs = append(errs, fmt.Errorf("%w: %s address %q: %v", ErrInvalidRecipient, group.field, address, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// addressList accepts a single address or a list of them, as decoded from JSON.
+func addressList(field string, value interface{}) ([]string, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if v == "" {
+			return nil, nil
+		}
+		return []string{v}, nil
+	case []string:
+		return v, nil
+	case []interface{}:
+		addresses := make([]string, 0, len(v))
+		for _, item := range v {
+			address, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("%w: %s must contain only strings", ErrInvalidRecipient, field)
+			}
+			addresses = append(addresses, address)
+		}
+		return addresses, nil
+	}
+	return nil, fmt.Errorf("%w: %s must be a string or a list of strings", ErrInvalidRecipient, field)
+}
@@ -0,0 +1,198 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"notification-service/internal/adapters/database/db"
+	"notification-service/internal/adapters/routing"
+	"notification-service/internal/core/repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultReplayLimit = 100
+	maxReplayLimit     = 1000
+)
+
+// ErrEmptyReplayFilter is returned when a replay would select every failed log.
+var ErrEmptyReplayFilter = errors.New("replay requires a template, recipient, time window or log IDs")
+
+// ErrNotReplayable is reported for logs that do not hold enough to send the
+// notification again.
+var ErrNotReplayable = errors.New("notification cannot be replayed")
+
+// ReplayFilter selects failed notification logs to re-enqueue.
+type ReplayFilter struct {
+	TemplateName string
+	Recipient    string
+	After        time.Time
+	Before       time.Time
+	LogIDs       []int32
+	Limit        int
+}
+
+// IsEmpty reports whether the filter has no selector set.
+func (f ReplayFilter) IsEmpty() bool {
+	return f.TemplateName == "" && f.Recipient == "" && f.After.IsZero() && f.Before.IsZero() && len(f.LogIDs) == 0
+}
+
+// ReplayResult describes the outcome of re-enqueueing one failed log.
+type ReplayResult struct {
+	LogID        int32
+	TemplateName string
+	Recipient    string
+	Topic        string
+	Err          error
+}
+
+// ReplayService re-drives failed notifications through Kafka.
+type ReplayService struct {
+	logRepo   repository.NotificationLogRepository
+	publisher repository.EventPublisher
+	routes    *routing.Table
+	logger    *logrus.Logger
+}
+
+func NewReplayService(
+	logRepo repository.NotificationLogRepository,
+	publisher repository.EventPublisher,
+	routes *routing.Table,
+	logger *logrus.Logger,
+) *ReplayService {
+	return &ReplayService{
+		logRepo:   logRepo,
+		publisher: publisher,
+		routes:    routes,
+		logger:    logger,
+	}
+}
+
+// Replay re-publishes every failed, not yet replayed log matching the filter
+// to the topic its template is routed from. Each event carries the original
+// log ID in HeaderReplayOf so the new attempt is linked back to it, and the
+// original log is stamped with replayed_at so it is not picked up twice.
+func (s *ReplayService) Replay(ctx context.Context, filter ReplayFilter) ([]ReplayResult, error) {
+	if filter.IsEmpty() {
+		return nil, ErrEmptyReplayFilter
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultReplayLimit
+	}
+	if limit > maxReplayLimit {
+		limit = maxReplayLimit
+	}
+
+	ids := filter.LogIDs
+	if ids == nil {
+		ids = []int32{}
+	}
+	logs, err := s.logRepo.ListFailedLogs(ctx, db.ListFailedNotificationLogsParams{
+		TemplateName:    nullString(filter.TemplateName),
+		Recipient:       nullString(filter.Recipient),
+		AttemptedAfter:  nullTime(filter.After),
+		AttemptedBefore: nullTime(filter.Before),
+		Ids:             ids,
+		RowLimit:        int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list failed notification logs: %w", err)
+	}
+
+	results := make([]ReplayResult, 0, len(logs))
+	for _, entry := range logs {
+		results = append(results, s.replayOne(ctx, entry))
+	}
+	return results, nil
+}
+
+func (s *ReplayService) replayOne(ctx context.Context, entry db.NotificationLog) ReplayResult {
+	result := ReplayResult{
+		LogID:        entry.ID,
+		TemplateName: entry.TemplateName,
+		Recipient:    entry.Recipient,
+	}
+	log := s.logger.WithFields(logrus.Fields{
+		"log_id":          entry.ID,
+		"notification_id": entry.NotificationID.String,
+		"recipient":       entry.Recipient,
+		"template":        entry.TemplateName,
+	})
+
+	// Logs written before the topic was recorded, or whose topic is no
+	// longer routed, fall back to the one topic routed to the template.
+	topic := entry.Topic.String
+	if _, ok := s.routes.Lookup(topic); !ok {
+		topic, ok = s.routes.TopicFor(entry.TemplateName)
+		if !ok {
+			result.Err = fmt.Errorf("no route configured for template %q", entry.TemplateName)
+			log.WithError(result.Err).Error("Cannot replay notification")
+			return result
+		}
+	}
+	result.Topic = topic
+
+	var data map[string]interface{}
+	if err := json.Unmarshal(entry.Data, &data); err != nil {
+		result.Err = fmt.Errorf("stored payload is not valid JSON: %w", err)
+		log.WithError(result.Err).Error("Cannot replay notification")
+		return result
+	}
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	// Inline attachment content is not logged, so the replay would fail
+	// for certain; only blob attachments can be sent again.
+	if filename, ok := droppedAttachment(data); ok {
+		result.Err = fmt.Errorf("%w: attachment %q was sent inline and its content is not logged", ErrNotReplayable, filename)
+		log.WithError(result.Err).Error("Cannot replay notification")
+		return result
+	}
+	// The replay goes out over the same channel as the original attempt.
+	if field, ok := repository.RecipientFields[entry.Channel]; ok {
+		if _, ok := data[field]; !ok {
+			data[field] = entry.Recipient
+		}
+	}
+
+	headers := map[string]string{
+		HeaderReplayOf: strconv.Itoa(int(entry.ID)),
+		HeaderChannel:  entry.Channel,
+	}
+	if entry.NotificationID.Valid {
+		// Keep the caller's notification ID so the replay shows up in its history.
+		headers[HeaderNotificationID] = entry.NotificationID.String
+	}
+	if entry.Locale.Valid {
+		headers[HeaderLocale] = entry.Locale.String
+	}
+	if err := s.publisher.Publish(ctx, topic, data, headers); err != nil {
+		result.Err = err
+		log.WithError(err).Error("Failed to re-enqueue notification")
+		return result
+	}
+
+	if err := s.logRepo.MarkReplayed(ctx, entry.ID, time.Now()); err != nil {
+		// The event is already queued; report it but do not fail the replay.
+		log.WithError(err).Error("Failed to mark notification log as replayed")
+	}
+
+	log.Info("Notification re-enqueued for replay")
+	return result
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf16"
+
+	"notification-service/internal/core/repository"
+)
+
+// ErrInvalidPhoneNumber marks SMS recipients that are not E.164 numbers.
+var ErrInvalidPhoneNumber = errors.New("invalid phone number")
+
+// DefaultSMSMaxSegments is used when SMSChannel.MaxSegments is zero.
+const DefaultSMSMaxSegments = 3
+
+// e164Pattern matches "+" and up to 15 digits with no leading zero.
+var e164Pattern = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+
+// ValidatePhoneNumber checks that number is in E.164 form, e.g. "+14155550123".
+func ValidatePhoneNumber(number string) error {
+	if !e164Pattern.MatchString(number) {
+		return fmt.Errorf("%w: %q must be in E.164 form, e.g. +14155550123", ErrInvalidPhoneNumber, number)
+	}
+	return nil
+}
+
+// SMSChannel configures text message delivery.
+type SMSChannel struct {
+	Sender repository.SMSSender
+	// MaxSegments caps how many concatenated parts a rendered message may
+	// be split into; longer messages fail rather than run up the bill.
+	MaxSegments int
+}
+
+func (c *SMSChannel) maxSegments() int {
+	if c.MaxSegments > 0 {
+		return c.MaxSegments
+	}
+	return DefaultSMSMaxSegments
+}
+
+// gsm7Basic and gsm7Extended are the characters of the GSM 03.38 default
+// alphabet; extended characters take two septets.
+const (
+	gsm7Basic = "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?" +
+		"¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà"
+	gsm7Extended = "^{}\\[~]|€\f"
+)
+
+// SMSSegments returns how many parts body is sent as. Messages in the GSM
+// alphabet fit 160 characters in one part and 153 per part once split;
+// anything else is sent as UCS-2, at 70 and 67 UTF-16 units.
+func SMSSegments(body string) int {
+	septets := 0
+	for _, r := range body {
+		switch {
+		case strings.ContainsRune(gsm7Basic, r):
+			septets++
+		case strings.ContainsRune(gsm7Extended, r):
+			septets += 2
+		default:
+			return segments(len(utf16.Encode([]rune(body))), 70, 67)
+		}
+	}
+	return segments(septets, 160, 153)
+}
+
+func segments(units, single, multi int) int {
+	if units <= single {
+		if units == 0 {
+			return 0
+		}
+		return 1
+	}
+	return (units + multi - 1) / multi
+}
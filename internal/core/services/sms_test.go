@@ -0,0 +1,74 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidatePhoneNumber(t *testing.T) {
+	tests := []struct {
+		number string
+		valid  bool
+	}{
+		{"+14155550123", true},
+		{"+491701234567", true},
+		{"+12", true},
+		{"+123456789012345", true},
+		{"+1234567890123456", false}, // 16 digits
+		{"+1", false},
+		{"14155550123", false},
+		{"+04155550123", false},
+		{"+1 415 555 0123", false},
+		{"+1-415-555-0123", false},
+		{"+1415555012a", false},
+		{"", false},
+		{"+", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.number, func(t *testing.T) {
+			err := ValidatePhoneNumber(tt.number)
+			if tt.valid && err != nil {
+				t.Errorf("ValidatePhoneNumber(%q) = %v, want nil", tt.number, err)
+			}
+			if !tt.valid && !errors.Is(err, ErrInvalidPhoneNumber) {
+				t.Errorf("ValidatePhoneNumber(%q) = %v, want ErrInvalidPhoneNumber", tt.number, err)
+			}
+		})
+	}
+}
+
+func TestSMSSegments(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"empty", "", 0},
+		{"short", "Hello", 1},
+		{"GSM 160", strings.Repeat("a", 160), 1},
+		{"GSM 161", strings.Repeat("a", 161), 2},
+		{"GSM 306", strings.Repeat("a", 306), 2},
+		{"GSM 307", strings.Repeat("a", 307), 3},
+		{"GSM basic non-ASCII", strings.Repeat("é", 160), 1},
+		{"GSM newline", strings.Repeat("a", 159) + "\n", 1},
+		{"extended counts twice", strings.Repeat("€", 80), 1},
+		{"extended over 160 septets", strings.Repeat("€", 80) + "a", 2},
+		{"extended 159 plus one", strings.Repeat("a", 159) + "{", 2},
+		{"UCS-2 70", strings.Repeat("ж", 70), 1},
+		{"UCS-2 71", strings.Repeat("ж", 71), 2},
+		{"UCS-2 134", strings.Repeat("ж", 134), 2},
+		{"UCS-2 135", strings.Repeat("ж", 135), 3},
+		{"one non-GSM character switches to UCS-2", strings.Repeat("a", 70) + "ж", 2},
+		{"non-GSM Latin", "naïve", 1},
+		{"emoji take two UTF-16 units", strings.Repeat("😀", 35), 1},
+		{"emoji over 70 units", strings.Repeat("😀", 36), 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SMSSegments(tt.body); got != tt.want {
+				t.Errorf("SMSSegments() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+
+	"notification-service/internal/core/repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+// TemplateService manages versioned templates. Every version is validated
+// before it is stored, so a broken template can never become active.
+type TemplateService struct {
+	store  repository.TemplateStore
+	logger *logrus.Logger
+}
+
+func NewTemplateService(store repository.TemplateStore, logger *logrus.Logger) *TemplateService {
+	return &TemplateService{
+		store:  store,
+		logger: logger,
+	}
+}
+
+// Create stores a new template as version 1.
+func (s *TemplateService) Create(ctx context.Context, tmpl *repository.Template, activate bool) (*repository.TemplateSummary, *repository.Template, error) {
+	if err := ValidateTemplate(tmpl); err != nil {
+		return nil, nil, err
+	}
+	summary, created, err := s.store.CreateTemplate(ctx, tmpl, activate)
+	if err != nil {
+		return nil, nil, err
+	}
+	s.logger.WithFields(logrus.Fields{"template": tmpl.Name, "locale": tmpl.Locale, "activated": activate}).Info("Template created")
+	return summary, created, nil
+}
+
+// Update stores the template as a new version of an existing template.
+func (s *TemplateService) Update(ctx context.Context, tmpl *repository.Template, activate bool) (*repository.TemplateSummary, *repository.Template, error) {
+	if err := ValidateTemplate(tmpl); err != nil {
+		return nil, nil, err
+	}
+	summary, created, err := s.store.AddVersion(ctx, tmpl, activate)
+	if err != nil {
+		return nil, nil, err
+	}
+	s.logger.WithFields(logrus.Fields{
+		"template":  tmpl.Name,
+		"locale":    tmpl.Locale,
+		"version":   created.Version,
+		"activated": activate,
+	}).Info("Template version added")
+	return summary, created, nil
+}
+
+func (s *TemplateService) List(ctx context.Context) ([]repository.TemplateSummary, error) {
+	return s.store.ListTemplates(ctx)
+}
+
+// Get returns a template summary and one of its versions; version 0 is the active one.
+func (s *TemplateService) Get(ctx context.Context, name, locale string, version int) (*repository.TemplateSummary, *repository.Template, error) {
+	summary, err := s.store.GetTemplateSummary(ctx, name, locale)
+	if err != nil {
+		return nil, nil, err
+	}
+	if version == 0 && summary.ActiveVersion == 0 {
+		// Nothing active yet; show the latest draft instead.
+		version = summary.LatestVersion
+	}
+	tmpl, err := s.store.GetVersion(ctx, name, locale, version)
+	if err != nil {
+		return nil, nil, err
+	}
+	return summary, tmpl, nil
+}
+
+func (s *TemplateService) Activate(ctx context.Context, name, locale string, version int) (*repository.TemplateSummary, error) {
+	summary, err := s.store.ActivateVersion(ctx, name, locale, version)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.WithFields(logrus.Fields{"template": name, "locale": locale, "version": version}).Info("Template version activated")
+	return summary, nil
+}
+
+func (s *TemplateService) Delete(ctx context.Context, name, locale string) error {
+	if err := s.store.DeleteTemplate(ctx, name, locale); err != nil {
+		return err
+	}
+	s.logger.WithFields(logrus.Fields{"template": name, "locale": locale}).Info("Template deleted")
+	return nil
+}
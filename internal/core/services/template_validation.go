@@ -0,0 +1,194 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"net/mail"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template/parse"
+
+	"notification-service/internal/adapters/mailme"
+	"notification-service/internal/core/repository"
+)
+
+var templateNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,127}$`)
+
+// TemplateValidationError lists every problem found in a template version.
+type TemplateValidationError struct {
+	Problems []string
+}
+
+func (e *TemplateValidationError) Error() string {
+	return "invalid template: " + strings.Join(e.Problems, "; ")
+}
+
+// IsTemplateValidation reports whether err is a *TemplateValidationError.
+func IsTemplateValidation(err error) bool {
+	var ve *TemplateValidationError
+	return errors.As(err, &ve)
+}
+
+// ValidateTemplate checks that a template's name is usable, that its subject
+// and bodies parse, and that the variables declared in its meta match the
+// placeholders the bodies actually use. body_html is only required of
+// templates without a body for another channel, as with file templates.
+func ValidateTemplate(tmpl *repository.Template) error {
+	var problems []string
+	if !templateNamePattern.MatchString(tmpl.Name) {
+		problems = append(problems, fmt.Sprintf("name %q must be lowercase letters, digits, '-' or '_'", tmpl.Name))
+	}
+	if locale, ok := repository.NormalizeLocale(tmpl.Locale); !ok || locale != tmpl.Locale {
+		problems = append(problems, fmt.Sprintf("locale %q is not a canonical locale tag such as \"de\" or \"de-DE\"", tmpl.Locale))
+	}
+	if strings.TrimSpace(tmpl.Subject) == "" {
+		problems = append(problems, "subject is required")
+	}
+	if strings.TrimSpace(tmpl.BodyHTML) == "" && tmpl.BodySMS == "" && tmpl.BodyWebhook == "" &&
+		tmpl.BodyChat == "" && tmpl.BodyInApp == "" {
+		problems = append(problems, "body_html is required unless the template has a body for another channel")
+	}
+	if tmpl.Meta.From != "" {
+		if _, err := mail.ParseAddress(tmpl.Meta.From); err != nil {
+			problems = append(problems, fmt.Sprintf("from %q is not a valid address: %v", tmpl.Meta.From, err))
+		}
+	}
+	if tmpl.Meta.Layout != "" {
+		problems = append(problems, "layouts are only available to file-based templates")
+	}
+
+	used := make(map[string]bool)
+	parts := []struct{ name, src string }{
+		{"subject", tmpl.Subject},
+		{"body_html", tmpl.BodyHTML},
+		{"body_text", tmpl.BodyText},
+		{"body_sms", tmpl.BodySMS},
+		{"body_webhook", tmpl.BodyWebhook},
+		{"body_chat", tmpl.BodyChat},
+		{"body_inapp", tmpl.BodyInApp},
+	}
+	for _, part := range parts {
+		vars, err := templateVariables(part.name, part.src)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s does not parse: %v", part.name, err))
+			continue
+		}
+		for v := range vars {
+			used[v] = true
+		}
+	}
+
+	declared := make(map[string]bool)
+	for _, v := range tmpl.Meta.Variables.Required {
+		declared[v] = true
+	}
+	for _, v := range tmpl.Meta.Variables.Optional {
+		if declared[v] {
+			problems = append(problems, fmt.Sprintf("variable %q is both required and optional", v))
+		}
+		declared[v] = true
+	}
+	builtin := make(map[string]bool)
+	for _, v := range repository.BuiltinVariables {
+		builtin[v] = true
+	}
+
+	for _, v := range sortedKeys(used) {
+		if !declared[v] && !builtin[v] {
+			problems = append(problems, fmt.Sprintf("placeholder %q is not declared in meta variables", v))
+		}
+	}
+	for _, v := range sortedKeys(declared) {
+		if !used[v] {
+			problems = append(problems, fmt.Sprintf("variable %q is declared but never used", v))
+		}
+	}
+	for _, v := range sortedKeys(tmpl.Meta.Defaults) {
+		if !declared[v] {
+			problems = append(problems, fmt.Sprintf("default %q is not a declared variable", v))
+		}
+	}
+
+	if len(problems) > 0 {
+		return &TemplateValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// templateVariables parses src and returns the top-level data keys it
+// references, e.g. {{.admin_name}} or {{$.app_name}}. Fields read inside
+// {{range}} or {{with}} refer to a nested value and are not counted.
+func templateVariables(name, src string) (map[string]bool, error) {
+	tmpl, err := template.New(name).Funcs(mailme.Funcs()).Parse(src)
+	if err != nil {
+		return nil, err
+	}
+
+	vars := make(map[string]bool)
+	for _, t := range tmpl.Templates() {
+		if t.Tree != nil && t.Tree.Root != nil {
+			collectVariables(t.Tree.Root, true, vars)
+		}
+	}
+	return vars, nil
+}
+
+func collectVariables(node parse.Node, rootDot bool, vars map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			collectVariables(child, rootDot, vars)
+		}
+	case *parse.ActionNode:
+		collectVariables(n.Pipe, rootDot, vars)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			collectVariables(cmd, rootDot, vars)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			collectVariables(arg, rootDot, vars)
+		}
+	case *parse.FieldNode:
+		if rootDot && len(n.Ident) > 0 {
+			vars[n.Ident[0]] = true
+		}
+	case *parse.ChainNode:
+		collectVariables(n.Node, rootDot, vars)
+	case *parse.VariableNode:
+		if len(n.Ident) > 1 && n.Ident[0] == "$" {
+			vars[n.Ident[1]] = true
+		}
+	case *parse.IfNode:
+		collectVariables(n.Pipe, rootDot, vars)
+		collectVariables(n.List, rootDot, vars)
+		collectVariables(n.ElseList, rootDot, vars)
+	case *parse.RangeNode:
+		collectVariables(n.Pipe, rootDot, vars)
+		collectVariables(n.List, false, vars)
+		collectVariables(n.ElseList, rootDot, vars)
+	case *parse.WithNode:
+		collectVariables(n.Pipe, rootDot, vars)
+		collectVariables(n.List, false, vars)
+		collectVariables(n.ElseList, rootDot, vars)
+	case *parse.TemplateNode:
+		collectVariables(n.Pipe, rootDot, vars)
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,131 @@
+package grpc_server
+
+import (
+	"context"
+	"errors"
+	"notification-service/api/proto/pb"
+	"notification-service/internal/adapters/database/db"
+	"notification-service/internal/core/services"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// InboxServer implements the InboxService gRPC API.
+type InboxServer struct {
+	pb.UnimplementedInboxServiceServer
+	inboxSvc *services.InboxService
+	logger   *logrus.Logger
+}
+
+// NewInboxServer creates a new in-app inbox server.
+func NewInboxServer(inboxSvc *services.InboxService, logger *logrus.Logger) *InboxServer {
+	return &InboxServer{
+		inboxSvc: inboxSvc,
+		logger:   logger,
+	}
+}
+
+func (s *InboxServer) ListInbox(ctx context.Context, req *pb.ListInboxRequest) (*pb.ListInboxResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	page, err := s.inboxSvc.List(ctx, req.UserId, req.UnreadOnly, int(req.PageSize), req.PageToken)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidPageToken) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		s.logger.WithError(err).WithField("user_id", req.UserId).Error("Failed to list inbox")
+		return nil, status.Error(codes.Internal, "failed to list inbox")
+	}
+
+	resp := &pb.ListInboxResponse{
+		NextPageToken: page.NextPageToken,
+		UnreadCount:   page.UnreadCount,
+	}
+	for _, entry := range page.Notifications {
+		resp.Notifications = append(resp.Notifications, toProtoInbox(entry))
+	}
+	return resp, nil
+}
+
+func (s *InboxServer) MarkRead(ctx context.Context, req *pb.MarkReadRequest) (*pb.MarkReadResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if len(req.Ids) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "ids is required")
+	}
+	updated, err := s.inboxSvc.MarkRead(ctx, req.UserId, req.Ids)
+	if err != nil {
+		s.logger.WithError(err).WithField("user_id", req.UserId).Error("Failed to mark inbox notifications read")
+		return nil, status.Error(codes.Internal, "failed to mark notifications read")
+	}
+	return &pb.MarkReadResponse{Updated: updated}, nil
+}
+
+func (s *InboxServer) MarkAllRead(ctx context.Context, req *pb.MarkAllReadRequest) (*pb.MarkAllReadResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	updated, err := s.inboxSvc.MarkAllRead(ctx, req.UserId)
+	if err != nil {
+		s.logger.WithError(err).WithField("user_id", req.UserId).Error("Failed to mark inbox read")
+		return nil, status.Error(codes.Internal, "failed to mark notifications read")
+	}
+	return &pb.MarkAllReadResponse{Updated: updated}, nil
+}
+
+// SubscribeInbox streams the user's new notifications until the client goes
+// away. When the subscription is dropped the stream ends with Unavailable,
+// and the client is expected to reconnect and call ListInbox to catch up.
+func (s *InboxServer) SubscribeInbox(req *pb.SubscribeInboxRequest, stream grpc.ServerStreamingServer[pb.InboxNotification]) error {
+	if req.UserId == "" {
+		return status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	updates, unsubscribe, err := s.inboxSvc.Subscribe(req.UserId)
+	if err != nil {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	defer unsubscribe()
+
+	// Headers tell the client the subscription is in place, so a ListInbox
+	// issued afterwards cannot miss a notification.
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case entry, ok := <-updates:
+			if !ok {
+				return status.Error(codes.Unavailable, "inbox subscription ended, reconnect and list the inbox to catch up")
+			}
+			if err := stream.Send(toProtoInbox(entry)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func toProtoInbox(entry db.InboxNotification) *pb.InboxNotification {
+	out := &pb.InboxNotification{
+		Id:             entry.ID,
+		UserId:         entry.UserID,
+		NotificationId: entry.NotificationID,
+		TemplateName:   entry.TemplateName,
+		Title:          entry.Title,
+		Body:           entry.Body,
+		CreatedAt:      timestamppb.New(entry.CreatedAt),
+	}
+	if entry.ReadAt.Valid {
+		out.ReadAt = timestamppb.New(entry.ReadAt.Time)
+	}
+	return out
+}
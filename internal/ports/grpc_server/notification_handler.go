@@ -2,35 +2,55 @@ package grpc_server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"notification-service/api/proto/pb"
 	"notification-service/internal/adapters/kafka"
+	"notification-service/internal/adapters/routing"
+	"notification-service/internal/core/repository"
+	"notification-service/internal/core/services"
 	"strings" // Import the strings package
 
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Server implements the gRPC server functionality.
 type Server struct {
 	pb.UnimplementedNotificationServiceServer
 	kafkaProducer *kafka.Producer
+	routes        *routing.Table
+	templateRepo  repository.TemplateRepository
+	attachments   *services.AttachmentResolver
+	replaySvc     *services.ReplayService
+	querySvc      *services.QueryService
+	previewSvc    *services.PreviewService
 	logger        *logrus.Logger
 }
 
 // NewGrpcServer creates a new gRPC server.
-func NewGrpcServer(producer *kafka.Producer, logger *logrus.Logger) *Server {
+func NewGrpcServer(producer *kafka.Producer, routes *routing.Table, templateRepo repository.TemplateRepository, attachments *services.AttachmentResolver, replaySvc *services.ReplayService, querySvc *services.QueryService, previewSvc *services.PreviewService, logger *logrus.Logger) *Server {
 	return &Server{
 		kafkaProducer: producer,
+		routes:        routes,
+		templateRepo:  templateRepo,
+		attachments:   attachments,
+		replaySvc:     replaySvc,
+		querySvc:      querySvc,
+		previewSvc:    previewSvc,
 		logger:        logger,
 	}
 }
 
 // SendNotification is the gRPC method handler. It now publishes an event to Kafka.
 func (s *Server) SendNotification(ctx context.Context, req *pb.SendNotificationRequest) (*pb.SendNotificationResponse, error) {
+	notificationID := services.NewNotificationID()
 	log := s.logger.WithFields(logrus.Fields{
-		"recipient": req.To,
-		"template":  req.TemplateName,
-		"source":    "grpc",
+		"notification_id": notificationID,
+		"recipient":       req.To,
+		"template":        req.TemplateName,
+		"source":          "grpc",
 	})
 	log.Info("Received gRPC request, preparing to publish to Kafka")
 
@@ -39,18 +59,76 @@ func (s *Server) SendNotification(ctx context.Context, req *pb.SendNotificationR
 	sanitizedTemplateName := strings.TrimRight(req.TemplateName, "!?. ")
 	// --- END: ADDED SANITIZATION ---
 
-	// The topic name is derived from the sanitized template name.
-	topic := fmt.Sprintf("notification.send-%s", sanitizedTemplateName)
+	// The topic is looked up in the routing table so the consumer resolves
+	// the same template on the other side.
+	topic, ok := s.routes.TopicFor(sanitizedTemplateName)
+	if !ok {
+		log.Warn("No route configured for template")
+		return &pb.SendNotificationResponse{
+			Success: false,
+			Message: "Unknown notification template.",
+		}, nil
+	}
+
+	locale, ok := repository.NormalizeLocale(req.Locale)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid locale %q", req.Locale)
+	}
+
+	// The primary route's channel applies unless the caller asks for
+	// another one. Without a channel the consumer also delivers over the
+	// topic's other routes, which have fixed recipients.
+	routes, _ := s.routes.Lookup(topic)
+	channel := routes[0].Channel
+	if req.Channel != "" {
+		if _, ok := repository.RecipientFields[req.Channel]; !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "unsupported channel %q", req.Channel)
+		}
+		channel = req.Channel
+	}
+	log = log.WithField("channel", channel)
 
 	// Convert the protobuf struct to a standard map[string]interface{}
 	data := req.Data.AsMap()
 
-	// The recipient's email must be included in the message payload
-	// for the consumer to use it.
-	data["email"] = req.To
+	// The recipient's email address, phone number, endpoint or user ID must
+	// be included in the message payload for the consumer to use it.
+	data[repository.RecipientFields[channel]] = req.To
+
+	// Check the data against the template's meta.json now, so the caller
+	// learns about missing variables instead of the send failing later.
+	template, err := s.templateRepo.GetTemplate(ctx, sanitizedTemplateName, locale)
+	if err != nil {
+		log.WithError(err).Error("Failed to load template for validation")
+		return nil, status.Error(codes.Internal, "failed to load notification template")
+	}
+	if _, err := template.PrepareData(data); err != nil {
+		log.WithError(err).Warn("Rejected notification with invalid template data")
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if channel != repository.ChannelEmail {
+		if err := checkChannel(channel, req, template); err != nil {
+			log.WithError(err).Warn("Rejected notification that cannot be sent over its channel")
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	} else if err := s.addEmailFields(ctx, req, template, data, log); err != nil {
+		return nil, err
+	}
 
 	// Publish the event to Kafka.
-	if err := s.kafkaProducer.Publish(ctx, topic, data); err != nil {
+	// The notification ID travels as a header so the consumer can log against it.
+	headers := map[string]string{services.HeaderNotificationID: notificationID}
+	if req.IdempotencyKey != "" {
+		headers[services.HeaderIdempotencyKey] = req.IdempotencyKey
+	}
+	if locale != "" {
+		headers[services.HeaderLocale] = locale
+	}
+	if req.Channel != "" {
+		headers[services.HeaderChannel] = channel
+	}
+	if err := s.kafkaProducer.Publish(ctx, topic, data, headers); err != nil {
 		log.WithError(err).Error("Failed to publish notification event to Kafka")
 		// Return a generic error to the client, as the failure is internal.
 		return &pb.SendNotificationResponse{
@@ -61,7 +139,91 @@ func (s *Server) SendNotification(ctx context.Context, req *pb.SendNotificationR
 
 	log.Info("Successfully published notification event to Kafka")
 	return &pb.SendNotificationResponse{
-		Success: true,
-		Message: "Notification has been successfully queued for sending.",
+		Success:        true,
+		Message:        "Notification has been successfully queued for sending.",
+		NotificationId: notificationID,
 	}, nil
 }
+
+// addEmailFields validates the email-only parts of a request and adds them
+// to the payload. It returns a gRPC status error.
+func (s *Server) addEmailFields(ctx context.Context, req *pb.SendNotificationRequest, template *repository.Template, data map[string]interface{}, log *logrus.Entry) error {
+	if !routing.HasBody(template, repository.ChannelEmail) {
+		return status.Errorf(codes.InvalidArgument, "template %q has no email body", template.Name)
+	}
+
+	// Extra addresses travel in the payload next to the primary "email".
+	recipients := services.Recipients{
+		To:      append([]string{req.To}, req.AdditionalTo...),
+		Cc:      req.Cc,
+		Bcc:     req.Bcc,
+		ReplyTo: req.ReplyTo,
+	}
+	if err := recipients.Validate(); err != nil {
+		log.WithError(err).Warn("Rejected notification with invalid recipients")
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	for key, addresses := range map[string][]string{
+		services.PayloadAdditionalTo: req.AdditionalTo,
+		services.PayloadCc:           req.Cc,
+		services.PayloadBcc:          req.Bcc,
+		services.PayloadReplyTo:      req.ReplyTo,
+	} {
+		if len(addresses) > 0 {
+			data[key] = addresses
+		}
+	}
+
+	// Attachments are checked against the size limits before they reach
+	// Kafka, and travel in the payload alongside the template data.
+	if len(req.Attachments) > 0 {
+		attachments := make([]services.Attachment, 0, len(req.Attachments))
+		for _, a := range req.Attachments {
+			attachments = append(attachments, services.Attachment{
+				Filename:    a.Filename,
+				ContentType: a.ContentType,
+				Content:     a.Content,
+				BlobPath:    a.BlobPath,
+				ContentID:   a.ContentId,
+			})
+		}
+		if err := s.attachments.Validate(ctx, attachments); err != nil {
+			if errors.Is(err, services.ErrInvalidAttachment) {
+				log.WithError(err).Warn("Rejected notification with invalid attachments")
+				return status.Error(codes.InvalidArgument, err.Error())
+			}
+			log.WithError(err).Error("Failed to check attachments")
+			return status.Error(codes.Internal, "failed to check attachments")
+		}
+		data[services.PayloadAttachments] = attachments
+	}
+	return nil
+}
+
+// checkChannel rejects requests that cannot go out over a channel other
+// than email.
+func checkChannel(channel string, req *pb.SendNotificationRequest, template *repository.Template) error {
+	if len(req.AdditionalTo) > 0 || len(req.Cc) > 0 || len(req.Bcc) > 0 || len(req.ReplyTo) > 0 {
+		return errors.New("additional_to, cc, bcc and reply_to are only supported for email")
+	}
+	if len(req.Attachments) > 0 {
+		return errors.New("attachments are only supported for email")
+	}
+
+	if !routing.HasBody(template, channel) {
+		return fmt.Errorf("template %q has no %s body", template.Name, channel)
+	}
+	switch channel {
+	case repository.ChannelSMS:
+		return services.ValidatePhoneNumber(req.To)
+	case repository.ChannelInApp:
+		if req.To == "" {
+			return errors.New("to must be the user ID whose inbox receives the notification")
+		}
+	default:
+		if req.To == "" {
+			return fmt.Errorf("to must name a registered %s endpoint", channel)
+		}
+	}
+	return nil
+}
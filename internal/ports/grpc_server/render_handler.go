@@ -0,0 +1,47 @@
+package grpc_server
+
+import (
+	"context"
+	"errors"
+	"notification-service/api/proto/pb"
+	"notification-service/internal/adapters/mailme"
+	"notification-service/internal/core/repository"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RenderTemplate renders a template against the supplied data and returns the
+// result, without touching SMTP or notification_logs.
+func (s *Server) RenderTemplate(ctx context.Context, req *pb.RenderTemplateRequest) (*pb.RenderTemplateResponse, error) {
+	log := s.logger.WithFields(logrus.Fields{
+		"template": req.TemplateName,
+		"source":   "grpc",
+	})
+
+	locale, ok := repository.NormalizeLocale(req.Locale)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid locale %q", req.Locale)
+	}
+
+	preview, err := s.previewSvc.Render(ctx, req.TemplateName, locale, req.To, req.Data.AsMap())
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrTemplateNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		case errors.Is(err, mailme.ErrRender):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		log.WithError(err).Error("Failed to render template preview")
+		return nil, status.Error(codes.Internal, "failed to render template")
+	}
+
+	return &pb.RenderTemplateResponse{
+		Subject:  preview.Subject,
+		BodyHtml: preview.BodyHTML,
+		BodyText: preview.BodyText,
+		Warnings: preview.Warnings,
+		Locale:   preview.Locale,
+	}, nil
+}
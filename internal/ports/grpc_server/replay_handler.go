@@ -0,0 +1,67 @@
+package grpc_server
+
+import (
+	"context"
+	"errors"
+	"notification-service/api/proto/pb"
+	"notification-service/internal/core/services"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ReplayNotifications re-enqueues failed notifications matching the request filters.
+func (s *Server) ReplayNotifications(ctx context.Context, req *pb.ReplayNotificationsRequest) (*pb.ReplayNotificationsResponse, error) {
+	filter := services.ReplayFilter{
+		TemplateName: req.TemplateName,
+		Recipient:    req.Recipient,
+		After:        timeOrZero(req.AttemptedAfter),
+		Before:       timeOrZero(req.AttemptedBefore),
+		LogIDs:       req.LogIds,
+		Limit:        int(req.Limit),
+	}
+	log := s.logger.WithFields(logrus.Fields{
+		"template":  filter.TemplateName,
+		"recipient": filter.Recipient,
+		"log_ids":   filter.LogIDs,
+		"source":    "grpc",
+	})
+	log.Info("Received replay request")
+
+	results, err := s.replaySvc.Replay(ctx, filter)
+	if err != nil {
+		if errors.Is(err, services.ErrEmptyReplayFilter) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		log.WithError(err).Error("Failed to replay notifications")
+		return nil, status.Error(codes.Internal, "failed to replay notifications")
+	}
+
+	resp := &pb.ReplayNotificationsResponse{}
+	for _, result := range results {
+		item := &pb.ReplayedNotification{
+			LogId:        result.LogID,
+			TemplateName: result.TemplateName,
+			Recipient:    result.Recipient,
+			Topic:        result.Topic,
+			Queued:       result.Err == nil,
+		}
+		if result.Err != nil {
+			item.Error = result.Err.Error()
+		}
+		resp.Notifications = append(resp.Notifications, item)
+	}
+
+	log.WithField("count", len(resp.Notifications)).Info("Replay request completed")
+	return resp, nil
+}
+
+func timeOrZero(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
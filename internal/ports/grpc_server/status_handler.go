@@ -0,0 +1,102 @@
+package grpc_server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"notification-service/api/proto/pb"
+	"notification-service/internal/adapters/database/db"
+	"notification-service/internal/core/services"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GetNotificationStatus returns the latest attempt for a notification ID, or a
+// single attempt by log ID.
+func (s *Server) GetNotificationStatus(ctx context.Context, req *pb.GetNotificationStatusRequest) (*pb.GetNotificationStatusResponse, error) {
+	var (
+		entry db.NotificationLog
+		err   error
+	)
+	switch {
+	case req.NotificationId != "":
+		entry, err = s.querySvc.GetLatestByNotificationID(ctx, req.NotificationId)
+	case req.LogId > 0:
+		entry, err = s.querySvc.GetStatus(ctx, req.LogId)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "notification_id or log_id is required")
+	}
+	if err != nil {
+		if errors.Is(err, services.ErrNotificationNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"notification_id": req.NotificationId,
+			"log_id":          req.LogId,
+		}).Error("Failed to get notification status")
+		return nil, status.Error(codes.Internal, "failed to get notification status")
+	}
+
+	return &pb.GetNotificationStatusResponse{Notification: toProtoLog(entry)}, nil
+}
+
+// ListNotifications pages through notification attempts, newest first.
+func (s *Server) ListNotifications(ctx context.Context, req *pb.ListNotificationsRequest) (*pb.ListNotificationsResponse, error) {
+	page, err := s.querySvc.List(ctx, services.ListFilter{
+		NotificationID: req.NotificationId,
+		Recipient:      req.Recipient,
+		TemplateName:   req.TemplateName,
+		Status:         req.Status,
+		After:          timeOrZero(req.AttemptedAfter),
+		Before:         timeOrZero(req.AttemptedBefore),
+		PageSize:       int(req.PageSize),
+		PageToken:      req.PageToken,
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidPageToken) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"recipient": req.Recipient,
+			"template":  req.TemplateName,
+		}).Error("Failed to list notifications")
+		return nil, status.Error(codes.Internal, "failed to list notifications")
+	}
+
+	resp := &pb.ListNotificationsResponse{NextPageToken: page.NextPageToken}
+	for _, entry := range page.Logs {
+		resp.Notifications = append(resp.Notifications, toProtoLog(entry))
+	}
+	return resp, nil
+}
+
+// toProtoLog converts a log row to its API shape. The payload is left out on
+// purpose: it can contain tokens and links meant only for the recipient.
+func toProtoLog(entry db.NotificationLog) *pb.NotificationLog {
+	out := &pb.NotificationLog{
+		Id:                entry.ID,
+		NotificationId:    entry.NotificationID.String,
+		Recipient:         entry.Recipient,
+		TemplateName:      entry.TemplateName,
+		Status:            entry.Status,
+		Details:           entry.Details.String,
+		AttemptedAt:       timestamppb.New(entry.AttemptedAt),
+		ReplayOf:          entry.ReplayOf.Int32,
+		Locale:            entry.Locale.String,
+		Provider:          entry.Provider.String,
+		ProviderMessageId: entry.ProviderMessageID.String,
+		Channel:           entry.Channel,
+		ResponseCode:      entry.ResponseCode.Int32,
+	}
+	if entry.ReplayedAt.Valid {
+		out.ReplayedAt = timestamppb.New(entry.ReplayedAt.Time)
+	}
+	var recipients services.Recipients
+	if err := json.Unmarshal(entry.Recipients, &recipients); err == nil {
+		out.To, out.Cc, out.Bcc, out.ReplyTo = recipients.To, recipients.Cc, recipients.Bcc, recipients.ReplyTo
+	}
+	return out
+}
@@ -0,0 +1,165 @@
+package grpc_server
+
+import (
+	"context"
+	"errors"
+	"notification-service/api/proto/pb"
+	"notification-service/internal/core/repository"
+	"notification-service/internal/core/services"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// TemplateServer implements the TemplateService gRPC API.
+type TemplateServer struct {
+	pb.UnimplementedTemplateServiceServer
+	templateSvc *services.TemplateService
+	logger      *logrus.Logger
+}
+
+// NewTemplateServer creates a new template management server.
+func NewTemplateServer(templateSvc *services.TemplateService, logger *logrus.Logger) *TemplateServer {
+	return &TemplateServer{
+		templateSvc: templateSvc,
+		logger:      logger,
+	}
+}
+
+func (s *TemplateServer) CreateTemplate(ctx context.Context, req *pb.CreateTemplateRequest) (*pb.TemplateResponse, error) {
+	summary, created, err := s.templateSvc.Create(ctx, fromProtoTemplate(req.Name, req.Locale, req.Content), req.Activate)
+	if err != nil {
+		return nil, s.templateError(err, "create", req.Name)
+	}
+	return &pb.TemplateResponse{Template: toProtoSummary(summary), Version: toProtoVersion(created)}, nil
+}
+
+func (s *TemplateServer) UpdateTemplate(ctx context.Context, req *pb.UpdateTemplateRequest) (*pb.TemplateResponse, error) {
+	summary, created, err := s.templateSvc.Update(ctx, fromProtoTemplate(req.Name, req.Locale, req.Content), req.Activate)
+	if err != nil {
+		return nil, s.templateError(err, "update", req.Name)
+	}
+	return &pb.TemplateResponse{Template: toProtoSummary(summary), Version: toProtoVersion(created)}, nil
+}
+
+func (s *TemplateServer) ListTemplates(ctx context.Context, req *pb.ListTemplatesRequest) (*pb.ListTemplatesResponse, error) {
+	summaries, err := s.templateSvc.List(ctx)
+	if err != nil {
+		return nil, s.templateError(err, "list", "")
+	}
+
+	resp := &pb.ListTemplatesResponse{}
+	for i := range summaries {
+		resp.Templates = append(resp.Templates, toProtoSummary(&summaries[i]))
+	}
+	return resp, nil
+}
+
+func (s *TemplateServer) GetTemplate(ctx context.Context, req *pb.GetTemplateRequest) (*pb.TemplateResponse, error) {
+	summary, tmpl, err := s.templateSvc.Get(ctx, req.Name, req.Locale, int(req.Version))
+	if err != nil {
+		return nil, s.templateError(err, "get", req.Name)
+	}
+	return &pb.TemplateResponse{Template: toProtoSummary(summary), Version: toProtoVersion(tmpl)}, nil
+}
+
+func (s *TemplateServer) ActivateVersion(ctx context.Context, req *pb.ActivateVersionRequest) (*pb.TemplateSummary, error) {
+	if req.Version <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "version is required")
+	}
+	summary, err := s.templateSvc.Activate(ctx, req.Name, req.Locale, int(req.Version))
+	if err != nil {
+		return nil, s.templateError(err, "activate", req.Name)
+	}
+	return toProtoSummary(summary), nil
+}
+
+func (s *TemplateServer) DeleteTemplate(ctx context.Context, req *pb.DeleteTemplateRequest) (*pb.DeleteTemplateResponse, error) {
+	if err := s.templateSvc.Delete(ctx, req.Name, req.Locale); err != nil {
+		return nil, s.templateError(err, "delete", req.Name)
+	}
+	return &pb.DeleteTemplateResponse{}, nil
+}
+
+// templateError maps template service errors to gRPC status codes.
+func (s *TemplateServer) templateError(err error, op, name string) error {
+	switch {
+	case services.IsTemplateValidation(err):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, repository.ErrTemplateExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, repository.ErrTemplateNotFound), errors.Is(err, repository.ErrTemplateVersionNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	}
+	s.logger.WithError(err).WithFields(logrus.Fields{"template": name, "op": op}).Error("Template operation failed")
+	return status.Errorf(codes.Internal, "failed to %s template", op)
+}
+
+func fromProtoTemplate(name, locale string, content *pb.TemplateVersion) *repository.Template {
+	tmpl := &repository.Template{
+		Name:        name,
+		Locale:      locale,
+		Subject:     content.GetSubject(),
+		BodyHTML:    content.GetBodyHtml(),
+		BodyText:    content.GetBodyText(),
+		BodySMS:     content.GetBodySms(),
+		BodyWebhook: content.GetBodyWebhook(),
+		BodyChat:    content.GetBodyChat(),
+		BodyInApp:   content.GetBodyInapp(),
+	}
+	if meta := content.GetMeta(); meta != nil {
+		tmpl.Meta = repository.TemplateMeta{
+			Description: meta.Description,
+			Variables: repository.TemplateVariables{
+				Required: meta.RequiredVariables,
+				Optional: meta.OptionalVariables,
+			},
+			InlineCSS: meta.InlineCss,
+			From:      meta.From,
+		}
+		if meta.Defaults != nil {
+			tmpl.Meta.Defaults = meta.Defaults.AsMap()
+		}
+	}
+	return tmpl
+}
+
+func toProtoVersion(tmpl *repository.Template) *pb.TemplateVersion {
+	version := &pb.TemplateVersion{
+		Version:     int32(tmpl.Version),
+		Subject:     tmpl.Subject,
+		BodyHtml:    tmpl.BodyHTML,
+		BodyText:    tmpl.BodyText,
+		BodySms:     tmpl.BodySMS,
+		BodyWebhook: tmpl.BodyWebhook,
+		BodyChat:    tmpl.BodyChat,
+		BodyInapp:   tmpl.BodyInApp,
+		Meta: &pb.TemplateMeta{
+			Description:       tmpl.Meta.Description,
+			RequiredVariables: tmpl.Meta.Variables.Required,
+			OptionalVariables: tmpl.Meta.Variables.Optional,
+			InlineCss:         tmpl.Meta.InlineCSS,
+			From:              tmpl.Meta.From,
+		},
+	}
+	if len(tmpl.Meta.Defaults) > 0 {
+		if defaults, err := structpb.NewStruct(tmpl.Meta.Defaults); err == nil {
+			version.Meta.Defaults = defaults
+		}
+	}
+	return version
+}
+
+func toProtoSummary(summary *repository.TemplateSummary) *pb.TemplateSummary {
+	return &pb.TemplateSummary{
+		Name:          summary.Name,
+		Locale:        summary.Locale,
+		ActiveVersion: int32(summary.ActiveVersion),
+		LatestVersion: int32(summary.LatestVersion),
+		CreatedAt:     timestamppb.New(summary.CreatedAt),
+		UpdatedAt:     timestamppb.New(summary.UpdatedAt),
+	}
+}